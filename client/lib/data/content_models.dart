@@ -17,6 +17,7 @@ class ContentItem {
   String fileName;
   String caption;
   bool isLandscape;
+  String id; // Assigned by the server, empty for new items
 
   ContentItem({
     required this.type,
@@ -24,6 +25,7 @@ class ContentItem {
     this.fileName = '',
     this.caption = '',
     this.isLandscape = false,
+    this.id = '',
   });
 
   // Helper to get string name for API
@@ -53,18 +55,15 @@ class ContentItem {
 }
 
 class ContentRequest {
-  final String id;
   final String documentName;
   final String subsection;
 
   ContentRequest({
-    required this.id,
     required this.documentName,
     required this.subsection,
   });
 
   Map<String, dynamic> toJson() => {
-        'ID': id,
         'DocumentName': documentName,
         'Subsection': subsection,
       };
@@ -75,12 +74,14 @@ class ContentResponse {
   final String message;
   final int noOfItems;
   final List<ContentItem> items;
+  final int version;
 
   ContentResponse({
     required this.ok,
     required this.message,
     required this.noOfItems,
     required this.items,
+    this.version = 0,
   });
 
   factory ContentResponse.fromJson(Map<String, dynamic> json) {
@@ -97,6 +98,7 @@ class ContentResponse {
     List<dynamic> fileNames = json['FileName'] ?? [];
     List<dynamic> captions = json['Captions'] ?? [];
     List<dynamic> landscapes = json['Landscape'] ?? [];
+    List<dynamic> itemIds = json['ItemIDs'] ?? [];
 
     List<ContentItem> parsedItems = [];
     
@@ -110,6 +112,7 @@ class ContentResponse {
           fileName: i < fileNames.length ? fileNames[i].toString() : '',
           caption: i < captions.length ? captions[i].toString() : '',
           isLandscape: i < landscapes.length ? (landscapes[i] as bool? ?? false) : false,
+          id: i < itemIds.length ? itemIds[i].toString() : '',
         ));
       }
     }
@@ -119,26 +122,26 @@ class ContentResponse {
       message: message,
       noOfItems: count,
       items: parsedItems,
+      version: json['Version'] as int? ?? 0,
     );
   }
 }
 
 class AddContentRequest {
-  final String id;
   final String documentName;
   final String subsection;
   final List<ContentItem> items;
+  final int version; // Version the items were loaded at
 
   AddContentRequest({
-    required this.id,
     required this.documentName,
     required this.subsection,
     required this.items,
+    required this.version,
   });
 
   Map<String, dynamic> toJson() {
     return {
-      'ID': id,
       'DocumentName': documentName,
       'Subsection': subsection,
       'NoOfItems': items.length,
@@ -147,6 +150,8 @@ class AddContentRequest {
       'FileName': items.map((e) => e.fileName).toList(),
       'Captions': items.map((e) => e.caption).toList(),
       'Landscape': items.map((e) => e.isLandscape).toList(),
+      'ItemIDs': items.map((e) => e.id).toList(),
+      'Version': version,
     };
   }
 }
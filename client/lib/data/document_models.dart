@@ -53,20 +53,20 @@ class DocumentDetails {
 }
 
 class DocumentDetailsRequest {
-  final String id;
   final String documentName;
   final DocumentDetails details;
+  final int version; // Version the details were loaded at
 
   DocumentDetailsRequest({
-    required this.id,
     required this.documentName,
     required this.details,
+    required this.version,
   });
 
   Map<String, dynamic> toJson() => {
-        'ID': id,
         'DocumentName': documentName,
         ...details.toJson(),
+        'Version': version,
       };
 }
 
@@ -74,11 +74,13 @@ class DocumentDetailsResponse {
   final bool ok;
   final String message;
   final DocumentDetails? details;
+  final int version;
 
   DocumentDetailsResponse({
     required this.ok,
     required this.message,
     this.details,
+    this.version = 0,
   });
 
   factory DocumentDetailsResponse.fromJson(Map<String, dynamic> json) {
@@ -86,6 +88,7 @@ class DocumentDetailsResponse {
       ok: json['OK'] as bool? ?? false,
       message: json['Message'] as String? ?? '',
       details: json['OK'] == true ? DocumentDetails.fromJson(json) : null,
+      version: json['Version'] as int? ?? 0,
     );
   }
 }
@@ -2,12 +2,42 @@ export 'document_models.dart';
 export 'subsystem_models.dart';
 export 'content_models.dart';
 
-class ClientID {
-  final String id;
+class LoginRequest {
+  final String username;
+  final String password;
 
-  ClientID({required this.id});
+  LoginRequest({required this.username, required this.password});
 
-  Map<String, dynamic> toJson() => {'ID': id};
+  Map<String, dynamic> toJson() => {'Username': username, 'Password': password};
+}
+
+class LoginResponse {
+  final bool ok;
+  final String message;
+  final String token;
+  final String username;
+  final String fullName;
+  final String role;
+
+  LoginResponse({
+    required this.ok,
+    required this.message,
+    this.token = '',
+    this.username = '',
+    this.fullName = '',
+    this.role = '',
+  });
+
+  factory LoginResponse.fromJson(Map<String, dynamic> json) {
+    return LoginResponse(
+      ok: json['OK'] as bool? ?? false,
+      message: json['Message'] as String? ?? '',
+      token: json['Token'] as String? ?? '',
+      username: json['Username'] as String? ?? '',
+      fullName: json['FullName'] as String? ?? '',
+      role: json['Role'] as String? ?? '',
+    );
+  }
 }
 
 class GetAllDocumentsResponse {
@@ -35,53 +65,50 @@ class GetAllDocumentsResponse {
 }
 
 class AddDocumentRequest {
-  final String id;
   final String name;
 
-  AddDocumentRequest({required this.id, required this.name});
+  AddDocumentRequest({required this.name});
 
-  Map<String, dynamic> toJson() => {'ID': id, 'Name': name};
+  Map<String, dynamic> toJson() => {'Name': name};
 }
 
 class CopyDocumentRequest {
   final String oldName;
   final String newName;
-  final String id;
 
   CopyDocumentRequest({
     required this.oldName,
     required this.newName,
-    required this.id,
   });
 
   Map<String, dynamic> toJson() => {
     'OldName': oldName,
     'NewName': newName,
-    'ID': id,
   };
 }
 
 class Ack {
   final bool ok;
   final String message;
+  final int version; // New version after a versioned write
 
-  Ack({required this.ok, required this.message});
+  Ack({required this.ok, required this.message, this.version = 0});
 
   factory Ack.fromJson(Map<String, dynamic> json) {
     return Ack(
       ok: json['OK'] as bool? ?? false,
       message: json['Message'] as String? ?? '',
+      version: json['Version'] as int? ?? 0,
     );
   }
 }
 
 class DeleteDocumentRequest {
   final String name;
-  final String password;
 
-  DeleteDocumentRequest({required this.name, required this.password});
+  DeleteDocumentRequest({required this.name});
 
-  Map<String, dynamic> toJson() => {'Name': name, 'Password': password};
+  Map<String, dynamic> toJson() => {'Name': name};
 }
 
 class PdfResponse {
@@ -29,29 +29,29 @@ class SubsystemDetails {
 }
 
 class SubsystemDetailsRequest {
-  final String id;
   final String documentName;
   final String satelliteClass;
   final String satelliteName;
   final String subsystemName;
   final String satelliteImage;
+  final int version; // Version the details were loaded at
 
   SubsystemDetailsRequest({
-    required this.id,
     required this.documentName,
     required this.satelliteClass,
     required this.satelliteName,
     required this.subsystemName,
     required this.satelliteImage,
+    required this.version,
   });
 
   Map<String, dynamic> toJson() => {
-        'ID': id,
         'DocumentName': documentName,
         'SatelliteClass': satelliteClass,
         'SatelliteName': satelliteName,
         'SubsystemName': subsystemName,
         'SatelliteImage': satelliteImage,
+        'Version': version,
       };
 }
 
@@ -62,6 +62,7 @@ class SubsystemDetailsResponse {
   final String satelliteName;
   final String subsystemName;
   final String satelliteImage;
+  final int version;
 
   SubsystemDetailsResponse({
     required this.ok,
@@ -70,6 +71,7 @@ class SubsystemDetailsResponse {
     this.satelliteName = '',
     this.subsystemName = '',
     this.satelliteImage = '',
+    this.version = 0,
   });
 
   factory SubsystemDetailsResponse.fromJson(Map<String, dynamic> json) {
@@ -80,6 +82,7 @@ class SubsystemDetailsResponse {
       satelliteName: json['SatelliteName'] as String? ?? '',
       subsystemName: json['SubsystemName'] as String? ?? '',
       satelliteImage: json['SatelliteImage'] as String? ?? '',
+      version: json['Version'] as int? ?? 0,
     );
   }
 }
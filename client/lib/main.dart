@@ -3,6 +3,7 @@ import 'package:provider/provider.dart';
 import 'providers/app_state.dart';
 import 'theme/app_theme.dart';
 import 'screens/home_screen.dart';
+import 'screens/login_screen.dart';
 
 import 'package:flutter/services.dart';
 
@@ -27,7 +28,9 @@ class MyApp extends StatelessWidget {
             theme: AppTheme.lightTheme,
             darkTheme: AppTheme.darkTheme,
             themeMode: appState.themeMode,
-            home: const HomeScreen(),
+            home: appState.isLoggedIn
+                ? const HomeScreen()
+                : const LoginScreen(),
           );
         },
       ),
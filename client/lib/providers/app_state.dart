@@ -1,4 +1,6 @@
 import 'package:flutter/material.dart';
+import 'package:universal_html/html.dart' as html;
+import '../services/api_service.dart';
 
 class NavigationItem {
   final String id;
@@ -15,7 +17,26 @@ class NavigationItem {
 }
 
 class AppState extends ChangeNotifier {
-  final String clientId = '${DateTime.now().millisecondsSinceEpoch}';
+  // Session State, kept in the browser so a reload does not log out
+  String? _token;
+  String _username = '';
+  String _fullName = '';
+  String _role = '';
+  bool get isLoggedIn => _token != null;
+  String get username => _username;
+  String get fullName => _fullName;
+  String get role => _role;
+  bool get isAdmin => _role == 'admin';
+
+  AppState() {
+    final storage = html.window.localStorage;
+    _token = storage['token'];
+    _username = storage['username'] ?? '';
+    _fullName = storage['fullName'] ?? '';
+    _role = storage['role'] ?? '';
+    ApiService.token = _token;
+    ApiService.onUnauthorized = _clearSession;
+  }
 
   // Document State
   String? _selectedDocument;
@@ -44,6 +65,47 @@ class AppState extends ChangeNotifier {
   bool get isSidebarVisible => _isSidebarVisible;
 
   // Actions
+  Future<String?> login(String username, String password) async {
+    final response = await ApiService().login(username, password);
+    if (!response.ok) {
+      return response.message;
+    }
+    _token = response.token;
+    _username = response.username;
+    _fullName = response.fullName;
+    _role = response.role;
+    final storage = html.window.localStorage;
+    storage['token'] = _token!;
+    storage['username'] = _username;
+    storage['fullName'] = _fullName;
+    storage['role'] = _role;
+    ApiService.token = _token;
+    notifyListeners();
+    return null;
+  }
+
+  Future<void> logout() async {
+    await ApiService().logout();
+    _clearSession();
+  }
+
+  // Drops the session, for example when the server no longer accepts it
+  void _clearSession() {
+    final storage = html.window.localStorage;
+    storage.remove('token');
+    storage.remove('username');
+    storage.remove('fullName');
+    storage.remove('role');
+    _token = null;
+    _username = '';
+    _fullName = '';
+    _role = '';
+    _selectedDocument = null;
+    _currentRoute = 'dashboard';
+    ApiService.token = null;
+    notifyListeners();
+  }
+
   void selectDocument(String? docName) {
     _selectedDocument = docName;
     // Reset to info or dashboard when document changes
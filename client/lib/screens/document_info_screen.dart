@@ -29,6 +29,7 @@ class _DocumentInfoScreenState extends State<DocumentInfoScreen> {
   bool _eidRequired = true;
   bool _resultFormatRequired = true;
   bool _isLoading = true;
+  int _version = 0; // Version the details were loaded at, sent back on save
 
   @override
   void initState() {
@@ -56,7 +57,7 @@ class _DocumentInfoScreenState extends State<DocumentInfoScreen> {
     setState(() => _isLoading = true);
 
     final response = await _apiService.getDocumentDetails(
-        appState.clientId, appState.selectedDocument!);
+        appState.selectedDocument!);
 
     if (mounted) {
       if (response.ok && response.details != null) {
@@ -71,6 +72,7 @@ class _DocumentInfoScreenState extends State<DocumentInfoScreen> {
         _secondApproverTitleController.text = d.secondApproverTitle;
         _eidRequired = d.eidRequired;
         _resultFormatRequired = d.resultFormatRequired;
+        _version = response.version;
       }
       setState(() => _isLoading = false);
     }
@@ -100,7 +102,8 @@ class _DocumentInfoScreenState extends State<DocumentInfoScreen> {
     );
 
     final result = await _apiService.addDocumentDetails(
-        appState.clientId, appState.selectedDocument!, details);
+        appState.selectedDocument!, details, _version);
+    if (result.ok) _version = result.version;
 
     if (mounted) {
       ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -335,7 +338,6 @@ class _DocumentInfoScreenState extends State<DocumentInfoScreen> {
       }
 
       final ack = await _apiService.processDesignDoc(
-        appState.clientId,
         appState.selectedDocument!,
         bytes.toList(),
         name,
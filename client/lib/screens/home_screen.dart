@@ -99,6 +99,16 @@ class HomeScreen extends StatelessWidget {
           ),
           const SizedBox(width: 8),
           const CircleAvatar(radius: 16, child: Icon(Icons.person, size: 20)),
+          const SizedBox(width: 8),
+          Text(
+            state.fullName.isNotEmpty ? state.fullName : state.username,
+            style: Theme.of(context).textTheme.bodyMedium,
+          ),
+          IconButton(
+            icon: const Icon(Icons.logout),
+            onPressed: () => state.logout(),
+            tooltip: 'Log Out',
+          ),
           const SizedBox(width: 16),
         ],
       ),
@@ -142,8 +152,7 @@ class _DashboardScreenState extends State<DashboardScreen> {
       _errorMessage = '';
     });
 
-    final appState = context.read<AppState>();
-    final response = await _apiService.getAllDocumentNames(appState.clientId);
+    final response = await _apiService.getAllDocumentNames();
 
     if (mounted) {
       setState(() {
@@ -194,6 +203,11 @@ class _DashboardScreenState extends State<DashboardScreen> {
             onPressed: _fetchDocuments,
             tooltip: 'Refresh',
           ),
+          IconButton(
+            icon: const Icon(Icons.logout),
+            onPressed: () => context.read<AppState>().logout(),
+            tooltip: 'Log Out',
+          ),
           const SizedBox(width: 16),
         ],
       ),
@@ -242,11 +256,7 @@ class _DashboardScreenState extends State<DashboardScreen> {
                   const SnackBar(content: Text('Creating document...')),
                 );
 
-                final appState = context.read<AppState>();
-                final result = await _apiService.addDocument(
-                  appState.clientId,
-                  name,
-                );
+                final result = await _apiService.addDocument(name);
 
                 if (context.mounted) {
                   ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -292,12 +302,7 @@ class _DashboardScreenState extends State<DashboardScreen> {
         context,
       ).showSnackBar(const SnackBar(content: Text('Copying document...')));
 
-      final appState = context.read<AppState>();
-      final result = await _apiService.copyDocument(
-        appState.clientId,
-        originalName,
-        newName,
-      );
+      final result = await _apiService.copyDocument(originalName, newName);
 
       if (context.mounted) {
         ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -352,12 +357,7 @@ class _DashboardScreenState extends State<DashboardScreen> {
   }
 
   void _showDeleteDocumentDialog(BuildContext context, String documentName) {
-    final TextEditingController passwordController = TextEditingController();
-
     Future<void> handleDelete() async {
-      final password = passwordController.text.trim();
-      if (password.isEmpty) return;
-
       Navigator.pop(context); // Close dialog
 
       // Show loading indicator
@@ -365,12 +365,7 @@ class _DashboardScreenState extends State<DashboardScreen> {
         context,
       ).showSnackBar(const SnackBar(content: Text('Deleting document...')));
 
-      final appState = context.read<AppState>();
-      final result = await _apiService.deleteDocument(
-        appState.clientId,
-        documentName,
-        password,
-      );
+      final result = await _apiService.deleteDocument(documentName);
 
       if (context.mounted) {
         ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -398,25 +393,8 @@ class _DashboardScreenState extends State<DashboardScreen> {
       builder: (context) {
         return AlertDialog(
           title: Text('Delete $documentName?'),
-          content: Column(
-            mainAxisSize: MainAxisSize.min,
-            children: [
-              const Text(
-                'This action cannot be undone. Please enter the administrator password to confirm.',
-              ),
-              const SizedBox(height: 16),
-              TextField(
-                controller: passwordController,
-                obscureText: true,
-                decoration: const InputDecoration(
-                  labelText: 'Password',
-                  border: OutlineInputBorder(),
-                  prefixIcon: Icon(Icons.lock),
-                ),
-                autofocus: true,
-                onSubmitted: (_) => handleDelete(),
-              ),
-            ],
+          content: const Text(
+            'The document will be moved to the Trash. An administrator can restore it until it is purged.',
           ),
           actions: [
             TextButton(
@@ -574,6 +552,8 @@ class _DashboardScreenState extends State<DashboardScreen> {
           ),
           itemCount: _filteredDocuments.length,
           itemBuilder: (context, index) {
+            // Only administrators may move documents to the Trash
+            final isAdmin = context.read<AppState>().isAdmin;
             final docName = _filteredDocuments[index];
             return Card(
               child: InkWell(
@@ -604,16 +584,17 @@ class _DashboardScreenState extends State<DashboardScreen> {
                           ],
                         ),
                       ),
-                      const PopupMenuItem(
-                        value: 'delete',
-                        child: Row(
-                          children: [
-                            Icon(Icons.delete, size: 20, color: Colors.red),
-                            SizedBox(width: 8),
-                            Text('Delete', style: TextStyle(color: Colors.red)),
-                          ],
+                      if (isAdmin)
+                        const PopupMenuItem(
+                          value: 'delete',
+                          child: Row(
+                            children: [
+                              Icon(Icons.delete, size: 20, color: Colors.red),
+                              SizedBox(width: 8),
+                              Text('Delete', style: TextStyle(color: Colors.red)),
+                            ],
+                          ),
                         ),
-                      ),
                     ],
                   ).then((value) {
                     if (value == 'copy') {
@@ -682,23 +663,24 @@ class _DashboardScreenState extends State<DashboardScreen> {
                                   ],
                                 ),
                               ),
-                              const PopupMenuItem<String>(
-                                value: 'delete',
-                                child: Row(
-                                  children: [
-                                    Icon(
-                                      Icons.delete,
-                                      size: 20,
-                                      color: Colors.red,
-                                    ),
-                                    SizedBox(width: 8),
-                                    Text(
-                                      'Delete',
-                                      style: TextStyle(color: Colors.red),
-                                    ),
-                                  ],
+                              if (isAdmin)
+                                const PopupMenuItem<String>(
+                                  value: 'delete',
+                                  child: Row(
+                                    children: [
+                                      Icon(
+                                        Icons.delete,
+                                        size: 20,
+                                        color: Colors.red,
+                                      ),
+                                      SizedBox(width: 8),
+                                      Text(
+                                        'Delete',
+                                        style: TextStyle(color: Colors.red),
+                                      ),
+                                    ],
+                                  ),
                                 ),
-                              ),
                             ],
                       ),
                     ],
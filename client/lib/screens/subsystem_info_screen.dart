@@ -25,6 +25,7 @@ class _SubsystemInfoScreenState extends State<SubsystemInfoScreen> {
   
   Uint8List? _imageData;
   bool _isLoading = true;
+  int _version = 0; // Version the details were loaded at, sent back on save
 
   @override
   void initState() {
@@ -47,10 +48,11 @@ class _SubsystemInfoScreenState extends State<SubsystemInfoScreen> {
     setState(() => _isLoading = true);
 
     final response = await _apiService.getSubsystemDetails(
-        appState.clientId, appState.selectedDocument!);
+        appState.selectedDocument!);
 
     if (mounted) {
       if (response.ok) {
+        _version = response.version;
         _satelliteNameController.text = response.satelliteName;
         _satelliteClassController.text = response.satelliteClass;
         _subsystemNameController.text = response.subsystemName;
@@ -98,7 +100,8 @@ class _SubsystemInfoScreenState extends State<SubsystemInfoScreen> {
     );
 
     final result = await _apiService.addSubsystemDetails(
-        appState.clientId, appState.selectedDocument!, details);
+        appState.selectedDocument!, details, _version);
+    if (result.ok) _version = result.version;
 
     if (mounted) {
       ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -6,19 +6,85 @@ import '../data/models.dart';
 class ApiService {
   late final String baseUrl;
 
+  // Session token sent as a Bearer token with every request
+  static String? token;
+
+  // Called when the server rejects the session token
+  static void Function()? onUnauthorized;
+
   ApiService() {
     baseUrl = Constants.baseUrl;
   }
 
-  Future<GetAllDocumentsResponse> getAllDocumentNames(String clientId) async {
+  Map<String, String> get _authHeaders => <String, String>{
+        if (token != null) 'Authorization': 'Bearer $token',
+      };
+
+  Map<String, String> get _headers => <String, String>{
+        'Content-Type': 'application/json; charset=UTF-8',
+        ..._authHeaders,
+      };
+
+  void _checkSession(int statusCode) {
+    if (statusCode == 401 && token != null) {
+      onUnauthorized?.call();
+    }
+  }
+
+  // A versioned write that is refused because it is based on an old version
+  // (409) or on none (428) still answers with a message to show.
+  bool _hasWriteAnswer(int statusCode) =>
+      statusCode == 200 || statusCode == 409 || statusCode == 428;
+
+  Future<LoginResponse> login(String username, String password) async {
+    try {
+      final response = await http.post(
+        Uri.parse('$baseUrl/login'),
+        headers: _headers,
+        body: jsonEncode(
+          LoginRequest(username: username, password: password).toJson(),
+        ),
+      );
+
+      if (response.statusCode == 200 || response.statusCode == 401) {
+        return LoginResponse.fromJson(jsonDecode(response.body));
+      } else {
+        return LoginResponse(
+          ok: false,
+          message: 'Server error: ${response.statusCode}',
+        );
+      }
+    } catch (e) {
+      return LoginResponse(ok: false, message: 'Connection error: $e');
+    }
+  }
+
+  Future<Ack> logout() async {
+    try {
+      final response = await http.post(
+        Uri.parse('$baseUrl/logout'),
+        headers: _headers,
+        body: jsonEncode(<String, dynamic>{}),
+      );
+
+      if (response.statusCode == 200) {
+        return Ack.fromJson(jsonDecode(response.body));
+      } else {
+        return Ack(ok: false, message: 'Server error: ${response.statusCode}');
+      }
+    } catch (e) {
+      return Ack(ok: false, message: 'Connection error: $e');
+    }
+  }
+
+  Future<GetAllDocumentsResponse> getAllDocumentNames() async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/getAllDocumentNames'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
-        body: jsonEncode(ClientID(id: clientId).toJson()),
+        headers: _headers,
+        body: jsonEncode(<String, dynamic>{}),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return GetAllDocumentsResponse.fromJson(jsonDecode(response.body));
@@ -38,17 +104,16 @@ class ApiService {
     }
   }
 
-  Future<Ack> addDocument(String clientId, String documentName) async {
+  Future<Ack> addDocument(String documentName) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/addDocument'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          AddDocumentRequest(id: clientId, name: documentName).toJson(),
+          AddDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return Ack.fromJson(jsonDecode(response.body));
@@ -61,7 +126,6 @@ class ApiService {
   }
 
   Future<Ack> processDesignDoc(
-    String clientId,
     String documentName,
     List<int> fileBytes,
     String fileName,
@@ -71,13 +135,14 @@ class ApiService {
         'POST',
         Uri.parse('$baseUrl/processDesignDoc'),
       );
-      request.fields['id'] = clientId;
+      request.headers.addAll(_authHeaders);
       request.fields['name'] = documentName;
       request.files.add(
         http.MultipartFile.fromBytes('file', fileBytes, filename: fileName),
       );
 
       final response = await request.send();
+      _checkSession(response.statusCode);
       final respStr = await response.stream.bytesToString();
 
       if (response.statusCode == 200) {
@@ -92,24 +157,21 @@ class ApiService {
   }
 
   Future<Ack> copyDocument(
-    String clientId,
     String oldName,
     String newName,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/copyDocument'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
           CopyDocumentRequest(
-            id: clientId,
             oldName: oldName,
             newName: newName,
           ).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return Ack.fromJson(jsonDecode(response.body));
@@ -122,19 +184,17 @@ class ApiService {
   }
 
   Future<DocumentDetailsResponse> getDocumentDetails(
-    String clientId,
     String documentName,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/getDocumentDetails'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          AddDocumentRequest(id: clientId, name: documentName).toJson(),
+          AddDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return DocumentDetailsResponse.fromJson(jsonDecode(response.body));
@@ -153,26 +213,25 @@ class ApiService {
   }
 
   Future<Ack> addDocumentDetails(
-    String clientId,
     String documentName,
     DocumentDetails details,
+    int version,
   ) async {
     try {
       final request = DocumentDetailsRequest(
-        id: clientId,
         documentName: documentName,
         details: details,
+        version: version,
       );
 
       final response = await http.post(
         Uri.parse('$baseUrl/addDocumentDetails'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(request.toJson()),
       );
+      _checkSession(response.statusCode);
 
-      if (response.statusCode == 200) {
+      if (_hasWriteAnswer(response.statusCode)) {
         return Ack.fromJson(jsonDecode(response.body));
       } else {
         return Ack(ok: false, message: 'Server error: ${response.statusCode}');
@@ -183,19 +242,17 @@ class ApiService {
   }
 
   Future<SubsystemDetailsResponse> getSubsystemDetails(
-    String clientId,
     String documentName,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/getSubsystemDetails'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          AddDocumentRequest(id: clientId, name: documentName).toJson(),
+          AddDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return SubsystemDetailsResponse.fromJson(jsonDecode(response.body));
@@ -214,29 +271,28 @@ class ApiService {
   }
 
   Future<Ack> addSubsystemDetails(
-    String clientId,
     String documentName,
     SubsystemDetails details,
+    int version,
   ) async {
     try {
       final request = SubsystemDetailsRequest(
-        id: clientId,
         documentName: documentName,
         satelliteClass: details.satelliteClass,
         satelliteName: details.satelliteName,
         subsystemName: details.subsystemName,
         satelliteImage: details.satelliteImage,
+        version: version,
       );
 
       final response = await http.post(
         Uri.parse('$baseUrl/addSubsystemDetails'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(request.toJson()),
       );
+      _checkSession(response.statusCode);
 
-      if (response.statusCode == 200) {
+      if (_hasWriteAnswer(response.statusCode)) {
         return Ack.fromJson(jsonDecode(response.body));
       } else {
         return Ack(ok: false, message: 'Server error: ${response.statusCode}');
@@ -247,24 +303,21 @@ class ApiService {
   }
 
   Future<ContentResponse> getContent(
-    String clientId,
     String documentName,
     String subsection,
   ) async {
     try {
       final request = ContentRequest(
-        id: clientId,
         documentName: documentName,
         subsection: subsection,
       );
 
       final response = await http.post(
         Uri.parse('$baseUrl/getContent'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(request.toJson()),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return ContentResponse.fromJson(jsonDecode(response.body));
@@ -287,28 +340,27 @@ class ApiService {
   }
 
   Future<Ack> addContent(
-    String clientId,
     String documentName,
     String subsection,
     List<ContentItem> items,
+    int version,
   ) async {
     try {
       final request = AddContentRequest(
-        id: clientId,
         documentName: documentName,
         subsection: subsection,
         items: items,
+        version: version,
       );
 
       final response = await http.post(
         Uri.parse('$baseUrl/addContent'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(request.toJson()),
       );
+      _checkSession(response.statusCode);
 
-      if (response.statusCode == 200) {
+      if (_hasWriteAnswer(response.statusCode)) {
         return Ack.fromJson(jsonDecode(response.body));
       } else {
         return Ack(ok: false, message: 'Server error: ${response.statusCode}');
@@ -319,19 +371,17 @@ class ApiService {
   }
 
   Future<PdfResponse> compileDocument(
-    String clientId,
     String documentName,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/compileDocument'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          AddDocumentRequest(id: clientId, name: documentName).toJson(),
+          AddDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return PdfResponse.fromJson(jsonDecode(response.body));
@@ -347,19 +397,17 @@ class ApiService {
   }
 
   Future<PdfResponse> getSignaturePage(
-    String clientId,
     String documentName,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/getSignaturePage'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          AddDocumentRequest(id: clientId, name: documentName).toJson(),
+          AddDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return PdfResponse.fromJson(jsonDecode(response.body));
@@ -375,23 +423,17 @@ class ApiService {
   }
 
   Future<Ack> deleteDocument(
-    String clientId,
     String documentName,
-    String password,
   ) async {
     try {
       final response = await http.post(
         Uri.parse('$baseUrl/deleteDocument'),
-        headers: <String, String>{
-          'Content-Type': 'application/json; charset=UTF-8',
-        },
+        headers: _headers,
         body: jsonEncode(
-          DeleteDocumentRequest(
-            name: documentName,
-            password: password,
-          ).toJson(),
+          DeleteDocumentRequest(name: documentName).toJson(),
         ),
       );
+      _checkSession(response.statusCode);
 
       if (response.statusCode == 200) {
         return Ack.fromJson(jsonDecode(response.body));
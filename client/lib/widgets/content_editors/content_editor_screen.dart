@@ -29,6 +29,7 @@ class _ContentEditorScreenState extends State<ContentEditorScreen> {
   final ApiService _apiService = ApiService();
   bool _isLoading = true;
   List<ContentItem> _items = [];
+  int _version = 0; // Version the items were loaded at, sent back on save
 
   // Edit State
   ContentItem? _editingItem;
@@ -61,7 +62,6 @@ class _ContentEditorScreenState extends State<ContentEditorScreen> {
 
     final subsection = _mapRouteToSubsection(widget.subsectionKey);
     final response = await _apiService.getContent(
-      appState.clientId,
       appState.selectedDocument!,
       subsection,
     );
@@ -69,6 +69,7 @@ class _ContentEditorScreenState extends State<ContentEditorScreen> {
     if (mounted) {
       setState(() {
         _items = response.items;
+        _version = response.version;
         _isLoading = false;
       });
     }
@@ -84,11 +85,12 @@ class _ContentEditorScreenState extends State<ContentEditorScreen> {
 
     final subsection = _mapRouteToSubsection(widget.subsectionKey);
     final result = await _apiService.addContent(
-      appState.clientId,
       appState.selectedDocument!,
       subsection,
       _items,
+      _version,
     );
+    if (result.ok) _version = result.version;
 
     if (mounted) {
       ScaffoldMessenger.of(context).hideCurrentSnackBar();
@@ -33,8 +33,8 @@ class _GenerateScreenState extends State<GenerateScreen> {
 
     try {
       final response = widget.isSignature
-          ? await _apiService.getSignaturePage(appState.clientId, appState.selectedDocument!)
-          : await _apiService.compileDocument(appState.clientId, appState.selectedDocument!);
+          ? await _apiService.getSignaturePage(appState.selectedDocument!)
+          : await _apiService.compileDocument(appState.selectedDocument!);
 
       if (response.ok && response.content.isNotEmpty) {
         _downloadPdf(response.content, widget.isSignature ? "Signature_Page.pdf" : "${appState.selectedDocument}.pdf");
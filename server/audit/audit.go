@@ -0,0 +1,205 @@
+// Package audit records every request that changes something, or compiles a
+// document, in the append-only audit log.
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes starting with these prefixes only read, except the compiles below.
+var readPrefixes = []string{"/get", "/export", "/check"}
+var readCompiles = []string{"/getSignaturePage", "/getDistributionRegister"}
+
+// Routes that are not worth an entry.
+var ignored = []string{"/events", "/heartbeatLock"}
+
+// Request fields that describe the target of a change. Passwords and
+// content values are left out.
+var targetFields = []string{"Subsection", "Item", "ID", "NoOfItems", "NewName", "Action", "Username", "Role", "Procedure", "Format"}
+
+// Request fields that are not a change in themselves.
+var unchangedFields = []string{"DocumentName", "Name", "OldName", "Version"}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func isMutation(path string) bool {
+	if slices.Contains(ignored, path) {
+		return false
+	}
+	if slices.Contains(readCompiles, path) {
+		return true
+	}
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware adds an entry for every mutating request after it is handled,
+// including those refused. The summary is the Message of the response
+// followed by what the request changes, see describeChanges. It must come
+// after auth.Middleware, except on /login, whose entries have no actor and
+// the username tried as target.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if !isMutation(path) {
+			c.Next()
+			return
+		}
+		var entry database.AuditEntry
+		entry.Actor = auth.GetUser(c).Username
+		entry.ClientID = c.ClientIP()
+		entry.Action = path
+		var changes string
+		entry.DocumentName, entry.Target, changes = describeRequest(c)
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		var response struct {
+			OK      bool
+			Message string
+		}
+		json.Unmarshal(recorder.body.Bytes(), &response)
+		entry.OK = response.OK && recorder.Status() < 400
+		entry.Summary = response.Message
+		if changes != "" {
+			entry.Summary = entry.Summary + " (" + changes + ")"
+		}
+		entry.Time = time.Now().UTC().Format(time.RFC3339)
+		if msg, ok := database.AddAuditEntry(entry); !ok {
+			fmt.Println("Audit entry for", entry.Action, "by", entry.Actor, "not written:", msg)
+		}
+	}
+}
+
+// describeRequest returns the document, the target of the request and the
+// changes it makes. The body is put back for the handler.
+func describeRequest(c *gin.Context) (string, string, string) {
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		target := ""
+		changes := ""
+		_, header, err := c.Request.FormFile("file")
+		if err == nil {
+			target = "file=" + header.Filename
+			changes = fmt.Sprintf("file: %d bytes", header.Size)
+		}
+		return c.PostForm("name"), target, changes
+	}
+	if c.Request.Body == nil {
+		return "", "", ""
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return "", "", ""
+	}
+	fields := make(map[string]any)
+	json.Unmarshal(data, &fields)
+	documentName := ""
+	for _, key := range []string{"DocumentName", "Name", "OldName"} {
+		name, ok := getField(fields, key).(string)
+		if ok && name != "" {
+			documentName = name
+			break
+		}
+	}
+	target := make([]string, 0)
+	for _, key := range targetFields {
+		value := getField(fields, key)
+		if value == nil {
+			continue
+		}
+		if procedure, ok := value.(map[string]any); ok {
+			value = getField(procedure, "Name")
+		}
+		target = append(target, fmt.Sprintf("%s=%v", key, value))
+	}
+	return documentName, strings.Join(target, " "), describeChanges(fields, "")
+}
+
+// describeChanges lists the fields of the request that are changed, other
+// than the document and the target, with the number of items of lists and
+// the fields of objects one level down, such as "Telecommands: 12 items" or
+// "Procedure.Steps: 5 items". Values are left out, and so are passwords.
+func describeChanges(fields map[string]any, prefix string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	changes := make([]string, 0)
+	for _, key := range keys {
+		if prefix == "" && containsFold(unchangedFields, key) {
+			continue
+		}
+		if _, isObject := fields[key].(map[string]any); prefix == "" && !isObject && containsFold(targetFields, key) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(key), "password") {
+			continue
+		}
+		name := prefix + key
+		switch value := fields[key].(type) {
+		case []any:
+			changes = append(changes, fmt.Sprintf("%s: %d items", name, len(value)))
+		case map[string]any:
+			if prefix == "" {
+				if nested := describeChanges(value, name+"."); nested != "" {
+					changes = append(changes, nested)
+				}
+			} else {
+				changes = append(changes, fmt.Sprintf("%s: %d items", name, len(value)))
+			}
+		default:
+			changes = append(changes, name)
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+func containsFold(list []string, key string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// getField returns the value of the key in the JSON object, matching the
+// key case-insensitively as the handlers do when they bind the body.
+func getField(fields map[string]any, key string) any {
+	if value, ok := fields[key]; ok {
+		return value
+	}
+	for name, value := range fields {
+		if strings.EqualFold(name, key) {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package auth checks the session token of every API request and the role
+// of the logged in user.
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"intDocument/server/database"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type denied struct {
+	OK      bool
+	Message string
+}
+
+// EventsPath is the event stream, the only route that takes the token as a
+// query parameter.
+const EventsPath = "/events"
+
+// GetToken returns the session token of the request, taken from the
+// "Authorization: Bearer" header or, on EventsPath only, from the "token"
+// query parameter, as EventSource cannot set headers. A token in the query
+// of any other route is ignored, so that it does not end up in URLs that
+// are logged or kept in the browser history.
+func GetToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	}
+	if c.Request.URL.Path == EventsPath {
+		return c.Query("token")
+	}
+	return ""
+}
+
+// GetSessionUser returns the user logged in with the token, and false if
+// the session has expired or the user no longer exists.
+func GetSessionUser(token string) (string, database.User, bool) {
+	msg, session, ok := database.GetSession(token)
+	if !ok {
+		return msg, database.User{}, false
+	}
+	return database.GetUser(session.Username)
+}
+
+// Middleware rejects requests without a valid session and stores the user
+// in the context.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		msg, user, ok := GetSessionUser(GetToken(c))
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, denied{OK: false, Message: msg})
+			return
+		}
+		c.Set("user", user)
+		c.Next()
+	}
+}
+
+// RequireRole lets only users with one of the roles through. Admins are
+// always let through.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := GetUser(c)
+		if user.Role != database.RoleAdmin && !slices.Contains(roles, user.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, denied{OK: false, Message: "Not allowed for role " + user.Role})
+			return
+		}
+		c.Next()
+	}
+}
+
+// DocumentAccess rejects requests for a document the user may not access at
+// the given level, see database.CheckAccess. The document is the "Name",
+// "DocumentName" or "OldName" of the JSON body, or the "name" field of a
+// form. Each handler binds only one of them, so a body that names
+// different documents in them is rejected, the checked name has to be the
+// one the handler uses. The body is put back for the handler. Documents in
+// the trash are not accessible at all. A document that does not exist is
+// let through, the handler creates it or reports that it doesn't exist.
+// This is only a first filter: the database functions check access again
+// for the user they are given.
+func DocumentAccess(level int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		documentName, ok := getDocumentName(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, denied{OK: false, Message: "The request names more than one document"})
+			return
+		}
+		if documentName != "" && database.DocumentExists(documentName) {
+			if database.IsTrashed(documentName) {
+				c.AbortWithStatusJSON(http.StatusNotFound, denied{OK: false, Message: documentName + " is in the Trash"})
+				return
+			}
+			msg, ok := database.CheckAccess(documentName, GetUser(c), level)
+			if !ok {
+				c.AbortWithStatusJSON(http.StatusForbidden, denied{OK: false, Message: msg})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// getDocumentName returns the document named in the request, and false if
+// the name fields of the body disagree.
+func getDocumentName(c *gin.Context) (string, bool) {
+	if c.ContentType() == gin.MIMEMultipartPOSTForm {
+		return c.PostForm("name"), true
+	}
+	if c.Request.Body == nil {
+		return "", true
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return "", true
+	}
+	var request struct {
+		Name         string
+		DocumentName string
+		OldName      string
+	}
+	json.Unmarshal(data, &request)
+	documentName := ""
+	for _, name := range []string{request.DocumentName, request.Name, request.OldName} {
+		if name == "" {
+			continue
+		}
+		if documentName != "" && name != documentName {
+			return "", false
+		}
+		documentName = name
+	}
+	return documentName, true
+}
+
+// GetUser returns the logged in user of the request.
+func GetUser(c *gin.Context) database.User {
+	value, ok := c.Get("user")
+	if !ok {
+		return database.User{}
+	}
+	return value.(database.User)
+}
+
+// GetWorkID returns a new name for the working directory of a compile or
+// upload, unique to the request and prefixed with the username.
+func GetWorkID(c *gin.Context) string {
+	data := make([]byte, 6)
+	rand.Read(data)
+	return GetUser(c).Username + "-" + hex.EncodeToString(data)
+}
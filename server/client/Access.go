@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getAccess(c *gin.Context) {
+	var addDocument AddDocument
+	var response AccessResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, access, ok := database.GetAccess(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Access Retrived"
+	response.Access = access
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addAccess(c *gin.Context) {
+	var request AccessRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	msg, ok := database.AddAccess(request.DocumentName, request.Access, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Access Added"
+	c.IndentedJSON(http.StatusOK, ack)
+}
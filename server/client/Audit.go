@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getAuditFilter(request AuditRequest) database.AuditFilter {
+	return database.AuditFilter{
+		Actor:        request.Actor,
+		Action:       request.Action,
+		DocumentName: request.DocumentName,
+		From:         request.From,
+		To:           request.To,
+		Offset:       request.Offset,
+		Limit:        request.Limit,
+	}
+}
+
+func getAuditLog(c *gin.Context) {
+	var request AuditRequest
+	var response AuditResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, "Audit Log")
+	msg, entries, total, ok := database.GetAuditEntries(getAuditFilter(request))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.Entries = entries
+	response.Total = total
+	response.OK = true
+	response.Message = "Audit Log Retrived"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+// exportAuditLog returns the matching entries as a CSV file. Offset and
+// Limit apply as for getAuditLog.
+// csvCell keeps a spreadsheet from reading a cell as a formula, by putting a
+// quote in front of a value that starts with =, +, - or @. The values come
+// from users and request bodies.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func exportAuditLog(c *gin.Context) {
+	var request AuditRequest
+	var ack FileResponse
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, "Audit Log Export")
+	msg, entries, _, ok := database.GetAuditEntries(getAuditFilter(request))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write([]string{"Time", "Actor", "ClientID", "Action", "DocumentName", "Target", "Summary", "OK"})
+	for _, entry := range entries {
+		w.Write([]string{entry.Time, csvCell(entry.Actor), csvCell(entry.ClientID), csvCell(entry.Action), csvCell(entry.DocumentName), csvCell(entry.Target), csvCell(entry.Summary), strconv.FormatBool(entry.OK)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		ack.OK = false
+		ack.Message = err.Error()
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.FileName = "audit.csv"
+	ack.Content = base64.StdEncoding.EncodeToString(buffer.Bytes())
+	ack.OK = true
+	ack.Message = "Audit Log Exported"
+	c.IndentedJSON(http.StatusOK, ack)
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/health"
+	"intDocument/server/typst"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getComments(c *gin.Context) {
+	var request CommentsRequest
+	var response CommentsResponse
+	response.Comments = make([]database.Comment, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection)
+	if request.OpenOnly {
+		msg, comments, ok := database.GetOpenComments(request.DocumentName, request.Subsection, auth.GetUser(c))
+		if !ok {
+			response.OK = false
+			response.Message = msg
+			c.IndentedJSON(http.StatusOK, response)
+			return
+		}
+		response.Comments = comments
+	} else {
+		msg, comments, ok := database.GetComments(request.DocumentName, auth.GetUser(c))
+		if !ok {
+			response.OK = false
+			response.Message = msg
+			c.IndentedJSON(http.StatusOK, response)
+			return
+		}
+		for _, comment := range comments.Comments {
+			if request.Subsection == "" || comment.Subsection == request.Subsection {
+				response.Comments = append(response.Comments, comment)
+			}
+		}
+	}
+	response.OK = true
+	response.Message = "Comments Retrived"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addComment(c *gin.Context) {
+	var request AddCommentRequest
+	var response CommentResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection, request.Item)
+	var comment database.Comment
+	comment.Subsection = request.Subsection
+	comment.Item = request.Item
+	comment.ItemID = request.ItemID
+	comment.Start = request.Start
+	comment.End = request.End
+	comment.Quote = request.Quote
+	comment.Text = request.Text
+	msg, comment, ok := database.AddComment(request.DocumentName, comment, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Comment Added"
+	response.Comment = comment
+	publish(c, events.CommentAdded, request.DocumentName, comment.Subsection, comment.Text)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func replyComment(c *gin.Context) {
+	var request CommentActionRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.ID)
+	reply := database.CommentReply{Text: request.Text}
+	msg, comment, ok := database.AddReply(request.DocumentName, request.ID, reply, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Reply Added"
+	publish(c, events.CommentAdded, request.DocumentName, comment.Subsection, request.Text)
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func resolveComment(c *gin.Context) {
+	setCommentResolved(c, true)
+}
+
+func reopenComment(c *gin.Context) {
+	setCommentResolved(c, false)
+}
+
+func setCommentResolved(c *gin.Context, resolved bool) {
+	var request CommentActionRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.ID, resolved)
+	msg, ok := database.ResolveComment(request.DocumentName, request.ID, auth.GetUser(c), resolved)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	if resolved {
+		ack.Message = "Comment Resolved"
+	} else {
+		ack.Message = "Comment Reopened"
+	}
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func compileReviewCopy(c *gin.Context) {
+	var addDocument AddDocument
+	var ack PDFResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := health.CompileReady()
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	id := auth.GetWorkID(c)
+	msg, ok = typst.InitializeReviewCopy(id, addDocument.Name, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	data, ok := typst.Compile(id)
+	publishCompile(c, addDocument.Name, ok)
+	ack.Content = base64.StdEncoding.EncodeToString(data)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Compilation Successful"
+	c.IndentedJSON(http.StatusOK, ack)
+}
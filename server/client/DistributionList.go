@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/health"
+	"intDocument/server/typst"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getDistributionList(c *gin.Context) {
+	var addDocument AddDocument
+	var response DistributionListResponse
+	response.Entries = make([]database.DistributionEntry, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, list, ok := database.GetDistributionList(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Distribution List Retrieved"
+	response.Entries = append(response.Entries, list.Entries...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addDistributionList(c *gin.Context) {
+	var request DistributionListRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	var list database.DistributionList
+	list.Entries = make([]database.DistributionEntry, 0)
+	list.Entries = append(list.Entries, request.Entries...)
+
+	msg, ok := database.AddDistributionList(request.DocumentName, list, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Distribution List Added"
+	publish(c, events.DetailsChanged, request.DocumentName, "DistributionList", ack.Message)
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getDistributionRegister(c *gin.Context) {
+	var addDocument AddDocument
+	var ack PDFResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := health.CompileReady()
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	id := auth.GetWorkID(c)
+	msg, ok = typst.GetDistributionRegister(id, addDocument.Name, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	data, ok := typst.Compile(id)
+	publishCompile(c, addDocument.Name, ok)
+	ack.Content = base64.StdEncoding.EncodeToString(data)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Compilation Successful"
+	c.IndentedJSON(http.StatusOK, ack)
+}
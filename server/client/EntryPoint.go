@@ -3,9 +3,13 @@ package client
 import (
 	"encoding/base64"
 	"fmt"
+	"intDocument/server/audit"
+	"intDocument/server/auth"
 	"intDocument/server/config"
 	"intDocument/server/database"
+	"intDocument/server/events"
 	"intDocument/server/handlers"
+	"intDocument/server/health"
 	"intDocument/server/typst"
 
 	"io/fs"
@@ -19,30 +23,111 @@ import (
 )
 
 func Listen(web fs.FS, port string) {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.LoggerWithFormatter(logFormatter), gin.Recovery())
 
 	r.Use(cors.New(cors.Config{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{"*"},
-		AllowHeaders: []string{"*"},
-		MaxAge:       24 * time.Hour,
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{"*"},
+		AllowHeaders:  []string{"*", "Authorization", "If-Match"},
+		ExposeHeaders: []string{"ETag"},
+		MaxAge:        24 * time.Hour,
 	}))
 
-	r.POST("/getAllDocumentNames", getAllDocumentNames)
-	r.POST("/addDocument", addDocument)
-	r.POST("/getDocumentDetails", getDocumentDetails)
-	r.POST("/addDocumentDetails", addDocumentDetails)
-	r.POST("/getSubsystemDetails", getSubsystemDetails)
-	r.POST("/addSubsystemDetails", addSubsystemDetails)
-	r.POST("/getContent", getContent)
-	r.POST("/addContent", addContent)
-	r.POST("/copyDocument", copyDocument)
-	r.POST("/deleteDocument", deleteDocument)
-
-	r.POST("/compileDocument", compileDocument)
-	r.POST("/getSignaturePage", getSignaturePage)
-
-	r.POST("/processDesignDoc", handlers.ProcessDesignDoc)
+	r.GET("/health", getHealth)
+	// Logins, failed or not, go to the audit log with the username tried.
+	r.POST("/login", audit.Middleware(), login)
+
+	// Every other API route needs a session, writes need the author role and
+	// deleting documents or managing users needs the admin role. The access
+	// list of the document named in the request is checked on top of that.
+	// Requests that change something, allowed or not, go to the audit log.
+	api := r.Group("/", auth.Middleware(), audit.Middleware(), auth.DocumentAccess(database.AccessView))
+	api.POST("/logout", logout)
+	api.GET(auth.EventsPath, getEvents)
+	api.POST("/getCurrentUser", getCurrentUser)
+	api.POST("/changePassword", changePassword)
+
+	api.POST("/getAllDocumentNames", getAllDocumentNames)
+	api.POST("/getDocumentDetails", getDocumentDetails)
+	api.POST("/getSubsystemDetails", getSubsystemDetails)
+	api.POST("/getContent", getContent)
+	api.POST("/getDistributionList", getDistributionList)
+	api.POST("/getLayout", getLayout)
+	api.POST("/getTelecommands", getTelecommands)
+	api.POST("/getTelemetry", getTelemetry)
+	api.POST("/exportTelemetry", exportTelemetry)
+	api.POST("/exportXTCE", exportXTCE)
+	api.POST("/getProcedures", getProcedures)
+	api.POST("/checkMnemonics", checkMnemonics)
+	api.POST("/getTestMatrixSettings", getTestMatrixSettings)
+	api.POST("/getTestMatrix", getTestMatrix)
+	api.POST("/getRequirements", getRequirements)
+	api.POST("/getTraceability", getTraceability)
+	api.POST("/getTestResults", getTestResults)
+	api.POST("/compileTestReport", compileTestReport)
+	api.POST("/exportSequence", exportSequence)
+	api.GET("/getSequenceSchema", getSequenceSchema)
+
+	api.POST("/compileDocument", compileDocument)
+	api.POST("/getSignaturePage", getSignaturePage)
+	api.POST("/getDistributionRegister", getDistributionRegister)
+	api.POST("/getComments", getComments)
+	api.POST("/addComment", addComment)
+	api.POST("/replyComment", replyComment)
+	api.POST("/resolveComment", resolveComment)
+	api.POST("/reopenComment", reopenComment)
+	api.POST("/compileReviewCopy", compileReviewCopy)
+	api.POST("/getSuggestions", getSuggestions)
+	api.POST("/addSuggestion", addSuggestion)
+	api.POST("/getSuggestionDiff", getSuggestionDiff)
+	api.POST("/getRevisions", getRevisions)
+	api.POST("/getLocks", getLocks)
+	api.POST("/getWorkflow", getWorkflow)
+	api.POST("/transitionWorkflow", transitionWorkflow)
+	api.POST("/getAccess", getAccess)
+	api.POST("/addAccess", auth.DocumentAccess(database.AccessOwner), addAccess)
+	api.POST("/copyDocument", auth.RequireRole(database.RoleAuthor), copyDocument)
+
+	author := api.Group("/", auth.RequireRole(database.RoleAuthor), auth.DocumentAccess(database.AccessEdit))
+	author.POST("/addDocument", addDocument)
+	author.POST("/addDocumentDetails", addDocumentDetails)
+	author.POST("/addSubsystemDetails", addSubsystemDetails)
+	author.POST("/addContent", addContent)
+	author.POST("/addDistributionList", addDistributionList)
+	author.POST("/addLayout", addLayout)
+	author.POST("/addTelecommands", addTelecommands)
+	author.POST("/importTelecommands", importTelecommands)
+	author.POST("/addTelemetry", addTelemetry)
+	author.POST("/importTelemetry", importTelemetry)
+	author.POST("/importXTCE", importXTCE)
+	author.POST("/addProcedure", addProcedure)
+	author.POST("/addTestMatrixSettings", addTestMatrixSettings)
+	author.POST("/addRequirements", addRequirements)
+	author.POST("/addTestResult", addTestResult)
+	author.POST("/uploadTelemetryLog", uploadTelemetryLog)
+	author.POST("/processDesignDoc", handlers.ProcessDesignDoc)
+	author.POST("/acceptSuggestion", acceptSuggestion)
+	author.POST("/rejectSuggestion", rejectSuggestion)
+	author.POST("/lockSection", lockSection)
+	author.POST("/heartbeatLock", heartbeatLock)
+	author.POST("/unlockSection", unlockSection)
+
+	admin := api.Group("/", auth.RequireRole())
+	admin.POST("/deleteDocument", deleteDocument)
+	admin.POST("/stealLock", stealLock)
+	admin.POST("/getUsers", getUsers)
+	admin.POST("/addUser", addUser)
+	admin.POST("/deleteUser", deleteUser)
+	admin.POST("/getAuditLog", getAuditLog)
+	admin.POST("/exportAuditLog", exportAuditLog)
+
+	// Documents in the trash are refused by DocumentAccess, so the trash
+	// routes are outside of api.
+	trash := r.Group("/", auth.Middleware(), audit.Middleware(), auth.RequireRole())
+	trash.POST("/getTrash", getTrash)
+	trash.POST("/restoreDocument", restoreDocument)
+	trash.POST("/purgeDocument", purgeDocument)
 
 	// Use NoRoute to serve static files to avoid conflict with API
 	r.NoRoute(func(c *gin.Context) {
@@ -74,17 +159,10 @@ func Listen(web fs.FS, port string) {
 }
 
 func getAllDocumentNames(c *gin.Context) {
-	var clientID ClientID
 	var names DocumentNamesResponse
 	names.DocumentNames = make([]string, 0)
-	if err := c.BindJSON(&clientID); err != nil {
-		names.OK = false
-		names.Message = "Bad Request"
-		c.IndentedJSON(http.StatusOK, names)
-		return
-	}
-	fmt.Println("Request ", clientID)
-	docNames, ok := database.GetAllDocumentNames()
+	fmt.Println("Request ", auth.GetUser(c).Username)
+	docNames, ok := database.GetVisibleDocumentNames(auth.GetUser(c))
 	if !ok {
 		names.OK = false
 		names.Message = "Unable to Get Document Names"
@@ -107,8 +185,8 @@ func addDocument(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", addDocument)
-	msg, ok := database.AddDocument(addDocument.Name)
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := database.AddDocument(addDocument.Name, auth.GetUser(c).Username)
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
@@ -117,6 +195,7 @@ func addDocument(c *gin.Context) {
 	}
 	ack.OK = true
 	ack.Message = "Document Added"
+	publish(c, events.DocumentCreated, addDocument.Name, "", "")
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
@@ -129,13 +208,21 @@ func getDocumentDetails(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, details)
 		return
 	}
-	fmt.Println("Request", addDocument)
-	msg, detailsDB, ok := database.GetDocumentDetails(addDocument.Name)
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	details = getDocumentDetailsResponse(addDocument.Name, auth.GetUser(c))
+	if details.OK {
+		setETag(c, details.Version)
+	}
+	c.IndentedJSON(http.StatusOK, details)
+}
+
+func getDocumentDetailsResponse(documentName string, user database.User) DocumentDetails {
+	var details DocumentDetails
+	msg, detailsDB, ok := database.GetDocumentDetails(documentName, user)
 	if !ok {
 		details.OK = false
 		details.Message = msg
-		c.IndentedJSON(http.StatusOK, details)
-		return
+		return details
 	}
 	details.OK = true
 	details.Message = "Document Added"
@@ -149,20 +236,22 @@ func getDocumentDetails(c *gin.Context) {
 	details.SecondApproverTitle = detailsDB.SecondApproverTitle
 	details.EID = detailsDB.EID
 	details.ResultFormat = detailsDB.ResultFormat
-
-	c.IndentedJSON(http.StatusOK, details)
+	details.Language = detailsDB.Language
+	details.MnemonicCheck = detailsDB.MnemonicCheck
+	details.Version = database.GetVersion(documentName, "DocumentDetails")
+	return details
 }
 
 func addDocumentDetails(c *gin.Context) {
 	var request DocumentDetailsRequest
-	var ack Ack
+	var ack WriteResponse
 	if err := c.BindJSON(&request); err != nil {
 		ack.OK = false
 		ack.Message = "Bad Request"
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", request.ID, request.DocumentName)
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
 	var details database.DocumentDetails
 	details.DocumentNumber = request.DocumentNumber
 	details.PreparedBy = request.PreparedBy
@@ -174,44 +263,80 @@ func addDocumentDetails(c *gin.Context) {
 	details.SecondApproverTitle = request.SecondApproverTitle
 	details.EID = request.EID
 	details.ResultFormat = request.ResultFormat
+	details.Language = request.Language
+	details.MnemonicCheck = request.MnemonicCheck
 
-	msg, ok := database.AddDocumentDetails(request.DocumentName, details)
+	baseVersion, ok := getBaseVersion(c, request.Version)
+	if !ok {
+		ack.OK = false
+		ack.Message = versionRequired
+		c.IndentedJSON(http.StatusPreconditionRequired, ack)
+		return
+	}
+	msg, version, ok := database.UpdateDocumentDetails(request.DocumentName, details, baseVersion, auth.GetUser(c))
+	if msg == database.VersionConflict {
+		current := getDocumentDetailsResponse(request.DocumentName, auth.GetUser(c))
+		current.OK = false
+		current.Message = msg
+		c.IndentedJSON(http.StatusConflict, current)
+		return
+	}
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
+	setETag(c, version)
 	ack.OK = true
 	ack.Message = "Document Details Added"
+	ack.Version = version
+	publish(c, events.DetailsChanged, request.DocumentName, "DocumentDetails", "")
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
 func addSubsystemDetails(c *gin.Context) {
 	var request SubsystemDetailsRequest
-	var ack Ack
+	var ack WriteResponse
 	if err := c.BindJSON(&request); err != nil {
 		ack.OK = false
 		ack.Message = "Bad Request"
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", request.ID, request.DocumentName)
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
 	var details database.SubsystemDetails
 	details.SubsystemName = request.SubsystemName
 	details.SatelliteName = request.SatelliteName
 	details.SatelliteClass = request.SatelliteClass
 	details.SatelliteImage = request.SatelliteImage
 
-	msg, ok := database.AddSubsystemDetails(request.DocumentName, details)
+	baseVersion, ok := getBaseVersion(c, request.Version)
+	if !ok {
+		ack.OK = false
+		ack.Message = versionRequired
+		c.IndentedJSON(http.StatusPreconditionRequired, ack)
+		return
+	}
+	msg, version, ok := database.UpdateSubsystemDetails(request.DocumentName, details, baseVersion, auth.GetUser(c))
+	if msg == database.VersionConflict {
+		current := getSubsystemDetailsResponse(request.DocumentName, auth.GetUser(c))
+		current.OK = false
+		current.Message = msg
+		c.IndentedJSON(http.StatusConflict, current)
+		return
+	}
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
+	setETag(c, version)
 	ack.OK = true
 	ack.Message = "Subsystem Details Added"
+	ack.Version = version
+	publish(c, events.DetailsChanged, request.DocumentName, "SubsystemDetails", "")
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
@@ -224,13 +349,21 @@ func getSubsystemDetails(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, details)
 		return
 	}
-	fmt.Println("Request", addDocument.ID, addDocument.Name)
-	msg, detailsDB, ok := database.GetSubsystemDetails(addDocument.Name)
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	details = getSubsystemDetailsResponse(addDocument.Name, auth.GetUser(c))
+	if details.OK {
+		setETag(c, details.Version)
+	}
+	c.IndentedJSON(http.StatusOK, details)
+}
+
+func getSubsystemDetailsResponse(documentName string, user database.User) SubsystemDetails {
+	var details SubsystemDetails
+	msg, detailsDB, ok := database.GetSubsystemDetails(documentName, user)
 	if !ok {
 		details.OK = false
 		details.Message = msg
-		c.IndentedJSON(http.StatusOK, details)
-		return
+		return details
 	}
 	details.OK = true
 	details.Message = "Document Added"
@@ -238,30 +371,39 @@ func getSubsystemDetails(c *gin.Context) {
 	details.SatelliteName = detailsDB.SatelliteName
 	details.SubsystemName = detailsDB.SubsystemName
 	details.SatelliteImage = detailsDB.SatelliteImage
-	c.IndentedJSON(http.StatusOK, details)
+	details.Version = database.GetVersion(documentName, "SubsystemDetails")
+	return details
 }
 
 func getContent(c *gin.Context) {
 	var contentRequest ContentRequest
 	var response ContentResponse
-	response.ContentType = make([]string, 0)
-	response.FileName = make([]string, 0)
-	response.Value = make([]string, 0)
-	response.Captions = make([]string, 0)
-	response.Landscape = make([]bool, 0)
 	if err := c.BindJSON(&contentRequest); err != nil {
 		response.OK = false
 		response.Message = "Bad Request"
 		c.IndentedJSON(http.StatusOK, response)
 		return
 	}
-	fmt.Println("Request", contentRequest.ID, contentRequest.DocumentName, contentRequest.Subsection)
-	msg, contentDB, ok := database.GetContent(contentRequest.DocumentName, contentRequest.Subsection)
+	fmt.Println("Request", auth.GetUser(c).Username, contentRequest.DocumentName, contentRequest.Subsection)
+	response = getContentResponse(contentRequest.DocumentName, contentRequest.Subsection, auth.GetUser(c))
+	if response.OK {
+		setETag(c, response.Version)
+	}
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func getContentResponse(documentName string, subsection string, user database.User) ContentResponse {
+	var response ContentResponse
+	response.ContentType = make([]string, 0)
+	response.FileName = make([]string, 0)
+	response.Value = make([]string, 0)
+	response.Captions = make([]string, 0)
+	response.Landscape = make([]bool, 0)
+	msg, contentDB, ok := database.GetContent(documentName, subsection, user)
 	if !ok {
 		response.OK = false
 		response.Message = msg
-		c.IndentedJSON(http.StatusOK, response)
-		return
+		return response
 	}
 	response.OK = true
 	response.Message = "Content Retrived"
@@ -271,19 +413,26 @@ func getContent(c *gin.Context) {
 	response.Value = append(response.Value, contentDB.Value...)
 	response.Captions = append(response.Captions, contentDB.Captions...)
 	response.Landscape = append(response.Landscape, contentDB.Landscape...)
-	c.IndentedJSON(http.StatusOK, response)
+	response.ItemIDs = contentDB.ItemIDs
+	response.Version = database.GetVersion(documentName, subsection)
+	lock, locked := database.GetLock(documentName, subsection, user)
+	if locked {
+		response.LockedBy = lock.Owner
+		response.LockExpires = lock.Expires.Format(time.RFC3339)
+	}
+	return response
 }
 
 func addContent(c *gin.Context) {
 	var contentRequest AddContentRequest
-	var ack Ack
+	var ack WriteResponse
 	if err := c.BindJSON(&contentRequest); err != nil {
 		ack.OK = false
 		ack.Message = "Bad Request"
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", contentRequest.ID, contentRequest.DocumentName, contentRequest.Subsection)
+	fmt.Println("Request", auth.GetUser(c).Username, contentRequest.DocumentName, contentRequest.Subsection)
 	var content database.Content
 	content.ContentType = make([]string, 0)
 	content.Value = make([]string, 0)
@@ -297,16 +446,34 @@ func addContent(c *gin.Context) {
 	content.FileName = append(content.FileName, contentRequest.FileName...)
 	content.Captions = append(content.Captions, contentRequest.Captions...)
 	content.Landscape = append(content.Landscape, contentRequest.Landscape...)
+	content.ItemIDs = contentRequest.ItemIDs
 
-	msg, ok := database.AddContent(contentRequest.DocumentName, contentRequest.Subsection, content)
+	baseVersion, ok := getBaseVersion(c, contentRequest.Version)
+	if !ok {
+		ack.OK = false
+		ack.Message = versionRequired
+		c.IndentedJSON(http.StatusPreconditionRequired, ack)
+		return
+	}
+	msg, version, ok := database.UpdateContent(contentRequest.DocumentName, contentRequest.Subsection, content, baseVersion, auth.GetUser(c))
+	if msg == database.VersionConflict {
+		current := getContentResponse(contentRequest.DocumentName, contentRequest.Subsection, auth.GetUser(c))
+		current.OK = false
+		current.Message = msg
+		c.IndentedJSON(http.StatusConflict, current)
+		return
+	}
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
+	setETag(c, version)
 	ack.OK = true
 	ack.Message = "Content Added"
+	ack.Version = version
+	publish(c, events.ContentSaved, contentRequest.DocumentName, contentRequest.Subsection, "")
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
@@ -319,8 +486,8 @@ func copyDocument(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", copyDocument)
-	msg, ok := database.CopyDocument(copyDocument.OldName, copyDocument.NewName)
+	fmt.Println("Request", auth.GetUser(c).Username, copyDocument.OldName, copyDocument.NewName)
+	msg, ok := database.CopyDocument(copyDocument.OldName, copyDocument.NewName, auth.GetUser(c))
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
@@ -329,16 +496,12 @@ func copyDocument(c *gin.Context) {
 	}
 	ack.OK = true
 	ack.Message = "Document Added"
+	publish(c, events.DocumentCopied, copyDocument.NewName, "", copyDocument.OldName)
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
-type DeleteDocumentRequest struct {
-	Name     string `json:"Name"`
-	Password string `json:"Password"`
-}
-
 func deleteDocument(c *gin.Context) {
-	var deleteRequest DeleteDocumentRequest
+	var deleteRequest AddDocument
 	var ack Ack
 	if err := c.BindJSON(&deleteRequest); err != nil {
 		ack.OK = false
@@ -346,16 +509,10 @@ func deleteDocument(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request Delete", deleteRequest.Name)
+	fmt.Println("Request Delete", auth.GetUser(c).Username, deleteRequest.Name)
 
-	if deleteRequest.Password != config.Config.DeletePassword {
-		ack.OK = false
-		ack.Message = "Invalid Password"
-		c.IndentedJSON(http.StatusOK, ack)
-		return
-	}
-
-	msg, ok := database.DeleteDocument(deleteRequest.Name)
+	retention := time.Duration(config.Config.TrashDays) * 24 * time.Hour
+	msg, ok := database.DeleteDocument(deleteRequest.Name, auth.GetUser(c).Username, retention)
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
@@ -363,7 +520,8 @@ func deleteDocument(c *gin.Context) {
 		return
 	}
 	ack.OK = true
-	ack.Message = "Document Deleted"
+	ack.Message = "Document moved to Trash"
+	publish(c, events.DocumentDeleted, deleteRequest.Name, "", "")
 	c.IndentedJSON(http.StatusOK, ack)
 }
 
@@ -376,8 +534,8 @@ func compileDocument(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", addDocument)
-	msg, ok := typst.InitializeNewDocument(addDocument.ID, addDocument.Name)
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := health.CompileReady()
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
@@ -385,7 +543,17 @@ func compileDocument(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	data, ok := typst.Compile(addDocument.ID)
+	id := auth.GetWorkID(c)
+	msg, ok = typst.InitializeNewDocument(id, addDocument.Name, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	data, ok := typst.Compile(id)
+	publishCompile(c, addDocument.Name, ok)
 	ack.Content = base64.StdEncoding.EncodeToString(data)
 	if !ok {
 		ack.OK = false
@@ -407,8 +575,17 @@ func getSignaturePage(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	fmt.Println("Request", addDocument)
-	msg, ok := typst.GetSignaturePage(addDocument.ID, addDocument.Name)
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := health.CompileReady()
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	id := auth.GetWorkID(c)
+	msg, ok = typst.GetSignaturePage(id, addDocument.Name, auth.GetUser(c))
 	if !ok {
 		ack.OK = false
 		ack.Message = msg
@@ -416,7 +593,8 @@ func getSignaturePage(c *gin.Context) {
 		c.IndentedJSON(http.StatusOK, ack)
 		return
 	}
-	data, ok := typst.Compile(addDocument.ID)
+	data, ok := typst.Compile(id)
+	publishCompile(c, addDocument.Name, ok)
 	ack.Content = base64.StdEncoding.EncodeToString(data)
 	if !ok {
 		ack.OK = false
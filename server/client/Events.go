@@ -0,0 +1,65 @@
+package client
+
+import (
+	"intDocument/server/auth"
+	"intDocument/server/events"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// publish sends an event about a document on behalf of the logged in user.
+func publish(c *gin.Context, eventType string, documentName string, subsection string, message string) {
+	var event events.Event
+	event.Type = eventType
+	event.DocumentName = documentName
+	event.Subsection = subsection
+	event.Username = auth.GetUser(c).Username
+	event.Message = message
+	events.Publish(event)
+}
+
+func publishCompile(c *gin.Context, documentName string, ok bool) {
+	message := "Compilation Successful"
+	if !ok {
+		message = "Compilation Failed"
+	}
+	publish(c, events.CompileFinished, documentName, "", message)
+}
+
+// getEvents streams the events as server-sent events until the client goes
+// away or its session ends. EventSource cannot set headers, so the token is
+// passed as the "token" query parameter. The session is checked again
+// before every event and ping, and the access to the document with the
+// user as they are now.
+func getEvents(c *gin.Context) {
+	token := auth.GetToken(c)
+	channel := events.Subscribe(auth.GetUser(c))
+	defer events.Unsubscribe(channel)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	ticker := time.NewTicker(25 * time.Second)
+	defer ticker.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event := <-channel:
+			_, user, ok := auth.GetSessionUser(token)
+			if !ok {
+				return false
+			}
+			if events.MayView(event, user) {
+				c.SSEvent(event.Type, event)
+			}
+			return true
+		case <-ticker.C:
+			if _, _, ok := auth.GetSessionUser(token); !ok {
+				return false
+			}
+			c.SSEvent("ping", "")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"intDocument/server/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getHealth(c *gin.Context) {
+	report := health.Check()
+	status := http.StatusOK
+	if !report.OK {
+		status = http.StatusServiceUnavailable
+	}
+	c.IndentedJSON(status, report)
+}
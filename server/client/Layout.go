@@ -0,0 +1,60 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getLayout(c *gin.Context) {
+	var addDocument AddDocument
+	var response LayoutResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, layout, ok := database.GetLayout(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Layout Retrieved"
+	response.Layout = layout
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addLayout(c *gin.Context) {
+	var request LayoutRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	if request.Layout.Sections == nil {
+		request.Layout.Sections = make(map[string]database.SectionLayout)
+	}
+	msg, ok := database.AddLayout(request.DocumentName, request.Layout, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Layout Added"
+	publish(c, events.DetailsChanged, request.DocumentName, "Layout", ack.Message)
+	c.IndentedJSON(http.StatusOK, ack)
+}
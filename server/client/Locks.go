@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/config"
+	"intDocument/server/database"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getLockDuration() time.Duration {
+	return time.Duration(config.Config.LockMinutes) * time.Minute
+}
+
+func getLocks(c *gin.Context) {
+	var addDocument AddDocument
+	var response LocksResponse
+	response.Locks = make([]database.SectionLock, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, locks, ok := database.GetLocks(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Locks Retrived"
+	response.Locks = locks
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func lockSection(c *gin.Context) {
+	takeLock(c, false)
+}
+
+func stealLock(c *gin.Context) {
+	takeLock(c, true)
+}
+
+func takeLock(c *gin.Context, steal bool) {
+	var request LockRequest
+	var response LockResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection, steal)
+	msg, lock, ok := database.LockSection(request.DocumentName, request.Subsection, auth.GetUser(c), getLockDuration(), steal)
+	response.Lock = lock
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Section Locked"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func heartbeatLock(c *gin.Context) {
+	var request LockRequest
+	var response LockResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, lock, ok := database.HeartbeatLock(request.DocumentName, request.Subsection, auth.GetUser(c), getLockDuration())
+	response.Lock = lock
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Lock Extended"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func unlockSection(c *gin.Context) {
+	var request LockRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	user := auth.GetUser(c)
+	fmt.Println("Request", user.Username, request.DocumentName, request.Subsection)
+	msg, ok := database.UnlockSection(request.DocumentName, request.Subsection, user, user.Role == database.RoleAdmin)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Section Unlocked"
+	c.IndentedJSON(http.StatusOK, ack)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/tmtc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func checkMnemonics(c *gin.Context) {
+	var addDocument AddDocument
+	var response MnemonicCheckResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, report, ok := tmtc.CheckDocument(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = fmt.Sprintf("%d Undefined, %d Misspelled, %d Unused Mnemonics", len(report.Undefined), len(report.Misspelled), len(report.UnusedTelecommands)+len(report.UnusedTelemetry))
+	response.Report = report
+	c.IndentedJSON(http.StatusOK, response)
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getProcedures(c *gin.Context) {
+	var addDocument AddDocument
+	var response ProceduresResponse
+	response.Procedures = make([]database.Procedure, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, procedures, ok := database.GetProcedures(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Procedures Retrieved"
+	response.Procedures = append(response.Procedures, procedures...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addProcedure(c *gin.Context) {
+	var request ProcedureRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Procedure.Name)
+	msg, ok := database.AddProcedure(request.DocumentName, request.Procedure, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Procedure Added"
+	publish(c, events.ContentSaved, request.DocumentName, "TestProcedures", request.Procedure.Name)
+	c.IndentedJSON(http.StatusOK, ack)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getRequirements(c *gin.Context) {
+	var request ContentRequest
+	var response RequirementsResponse
+	response.Requirements = make([]database.Requirement, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection)
+	msg, list, ok := database.GetRequirements(request.DocumentName, request.Subsection, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Requirements Retrieved"
+	response.Requirements = append(response.Requirements, list.Requirements...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addRequirements(c *gin.Context) {
+	var request RequirementsRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection)
+	var list database.RequirementList
+	list.Requirements = make([]database.Requirement, 0)
+	list.Requirements = append(list.Requirements, request.Requirements...)
+	msg, ok := database.AddRequirements(request.DocumentName, request.Subsection, list, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Requirements Added"
+	publish(c, events.ContentSaved, request.DocumentName, request.Subsection, ack.Message)
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getTraceability(c *gin.Context) {
+	var addDocument AddDocument
+	var response TraceabilityResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, trace, ok := database.GetTraceability(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	uncovered := 0
+	for _, row := range trace.Rows {
+		if !row.Covered {
+			uncovered = uncovered + 1
+		}
+	}
+	response.OK = true
+	response.Message = fmt.Sprintf("%d Requirements, %d not covered, %d unknown references", len(trace.Rows), uncovered, len(trace.Unknown))
+	response.Traceability = trace
+	c.IndentedJSON(http.StatusOK, response)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/sequence"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportSequence converts the structured procedures of the document, or only
+// the one named in the request, into a checkout sequence file.
+func exportSequence(c *gin.Context) {
+	var request SequenceRequest
+	var response SequenceResponse
+	response.Problems = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Procedure, request.Format)
+	msg, procedures, ok := database.GetProcedures(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	if request.Procedure != "" {
+		selected := make([]database.Procedure, 0)
+		for _, procedure := range procedures {
+			if procedure.Name == request.Procedure {
+				selected = append(selected, procedure)
+			}
+		}
+		if len(selected) == 0 {
+			response.OK = false
+			response.Message = "Procedure " + request.Procedure + " doesn't exist"
+			c.IndentedJSON(http.StatusOK, response)
+			return
+		}
+		procedures = selected
+	}
+	msg, tcList, ok := database.GetTelecommands(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, tmList, ok := database.GetTelemetry(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	file, problems := sequence.Build(request.DocumentName, procedures, tcList.Telecommands, tmList.Parameters)
+	if len(problems) > 0 {
+		response.OK = false
+		response.Message = "Sequence not exported, please correct the problems"
+		response.Problems = problems
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	data, err := sequence.Encode(file, request.Format)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot export Sequence: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	extension := strings.ToLower(request.Format)
+	if extension == "" {
+		extension = "json"
+	}
+	name := request.DocumentName
+	if request.Procedure != "" {
+		name = name + "-" + request.Procedure
+	}
+	response.OK = true
+	response.Message = "Sequence Exported"
+	response.FileName = name + ".sequence." + extension
+	response.Content = base64.StdEncoding.EncodeToString(data)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func getSequenceSchema(c *gin.Context) {
+	var response FileResponse
+	response.OK = true
+	response.Message = "Sequence Schema"
+	response.FileName = "ist-sequence.schema.json"
+	response.Content = base64.StdEncoding.EncodeToString([]byte(sequence.Schema))
+	c.IndentedJSON(http.StatusOK, response)
+}
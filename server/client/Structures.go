@@ -1,8 +1,9 @@
 package client
 
-type ClientID struct {
-	ID string
-}
+import (
+	"intDocument/server/database"
+	"intDocument/server/tmtc"
+)
 
 type DocumentNamesResponse struct {
 	OK            bool
@@ -11,7 +12,6 @@ type DocumentNamesResponse struct {
 }
 
 type AddDocument struct {
-	ID   string
 	Name string
 }
 
@@ -20,6 +20,13 @@ type Ack struct {
 	Message string
 }
 
+// WriteResponse acknowledges a versioned write with the new version.
+type WriteResponse struct {
+	Version int
+	OK      bool
+	Message string
+}
+
 type DocumentDetails struct {
 	DocumentNumber      string
 	PreparedBy          string
@@ -31,12 +38,14 @@ type DocumentDetails struct {
 	SecondApproverTitle string
 	EID                 bool
 	ResultFormat        bool
+	Language            string
+	MnemonicCheck       bool
+	Version             int
 	OK                  bool
 	Message             string
 }
 
 type DocumentDetailsRequest struct {
-	ID                  string
 	DocumentName        string
 	DocumentNumber      string
 	PreparedBy          string
@@ -48,6 +57,9 @@ type DocumentDetailsRequest struct {
 	SecondApproverTitle string
 	EID                 bool
 	ResultFormat        bool
+	Language            string
+	MnemonicCheck       bool
+	Version             *int
 }
 
 type SubsystemDetails struct {
@@ -55,21 +67,21 @@ type SubsystemDetails struct {
 	SatelliteName  string
 	SubsystemName  string
 	SatelliteImage string
+	Version        int
 	OK             bool
 	Message        string
 }
 
 type SubsystemDetailsRequest struct {
-	ID             string
 	DocumentName   string
 	SatelliteClass string
 	SatelliteName  string
 	SubsystemName  string
 	SatelliteImage string
+	Version        *int
 }
 
 type ContentRequest struct {
-	ID           string
 	DocumentName string
 	Subsection   string
 }
@@ -81,12 +93,15 @@ type ContentResponse struct {
 	Value       []string
 	Captions    []string
 	Landscape   []bool
+	ItemIDs     []string
+	Version     int
+	LockedBy    string
+	LockExpires string
 	OK          bool
 	Message     string
 }
 
 type AddContentRequest struct {
-	ID           string
 	DocumentName string
 	Subsection   string
 	NoOfItems    int
@@ -95,10 +110,11 @@ type AddContentRequest struct {
 	Value        []string
 	Captions     []string
 	Landscape    []bool
+	ItemIDs      []string
+	Version      *int
 }
 
 type CopyDocument struct {
-	ID      string
 	OldName string
 	NewName string
 }
@@ -108,3 +124,353 @@ type PDFResponse struct {
 	OK      bool
 	Message string
 }
+
+type DistributionListRequest struct {
+	DocumentName string
+	Entries      []database.DistributionEntry
+}
+
+type DistributionListResponse struct {
+	Entries []database.DistributionEntry
+	OK      bool
+	Message string
+}
+
+type LayoutRequest struct {
+	DocumentName string
+	Layout       database.DocumentLayout
+}
+
+type LayoutResponse struct {
+	Layout  database.DocumentLayout
+	OK      bool
+	Message string
+}
+
+type TelecommandsRequest struct {
+	DocumentName string
+	Telecommands []database.Telecommand
+}
+
+type TelecommandsResponse struct {
+	Telecommands []database.Telecommand
+	OK           bool
+	Message      string
+}
+
+type ImportRequest struct {
+	DocumentName string
+	Format       string
+	Data         string
+	Replace      bool
+}
+
+type ValidationResponse struct {
+	Count    int
+	Problems []string
+	Warnings []string
+	OK       bool
+	Message  string
+}
+
+type TelemetryRequest struct {
+	DocumentName string
+	Parameters   []database.TelemetryParameter
+}
+
+type TelemetryResponse struct {
+	Parameters []database.TelemetryParameter
+	OK         bool
+	Message    string
+}
+
+type FileResponse struct {
+	FileName string
+	Content  string
+	OK       bool
+	Message  string
+}
+
+type ProceduresResponse struct {
+	Procedures []database.Procedure
+	OK         bool
+	Message    string
+}
+
+type ProcedureRequest struct {
+	DocumentName string
+	Procedure    database.Procedure
+}
+
+type MnemonicCheckResponse struct {
+	Report  tmtc.MnemonicReport
+	OK      bool
+	Message string
+}
+
+type TestMatrixSettingsRequest struct {
+	DocumentName string
+	Settings     database.TestMatrixSettings
+}
+
+type TestMatrixSettingsResponse struct {
+	Settings database.TestMatrixSettings
+	OK       bool
+	Message  string
+}
+
+type TestMatrixResponse struct {
+	Matrix  database.TestMatrix
+	OK      bool
+	Message string
+}
+
+type RequirementsRequest struct {
+	DocumentName string
+	Subsection   string
+	Requirements []database.Requirement
+}
+
+type RequirementsResponse struct {
+	Requirements []database.Requirement
+	OK           bool
+	Message      string
+}
+
+type TraceabilityResponse struct {
+	Traceability database.Traceability
+	OK           bool
+	Message      string
+}
+
+type TestResultsResponse struct {
+	Results []database.ProcedureResult
+	OK      bool
+	Message string
+}
+
+type TestResultRequest struct {
+	DocumentName string
+	Result       database.ProcedureResult
+}
+
+type TelemetryLogRequest struct {
+	DocumentName string
+	Procedure    string
+	TestDate     string
+	Operator     string
+	Data         string
+}
+
+type LogEvaluationResponse struct {
+	Evaluation tmtc.LogEvaluation
+	OK         bool
+	Message    string
+}
+
+type SequenceRequest struct {
+	DocumentName string
+	Procedure    string
+	Format       string
+}
+
+type SequenceResponse struct {
+	FileName string
+	Content  string
+	Problems []string
+	OK       bool
+	Message  string
+}
+
+type LoginRequest struct {
+	Username string
+	Password string
+}
+
+type LoginResponse struct {
+	Token    string
+	Username string
+	FullName string
+	Role     string
+	Expires  string
+	OK       bool
+	Message  string
+}
+
+type UserInfo struct {
+	Username string
+	FullName string
+	Role     string
+}
+
+type UserResponse struct {
+	User    UserInfo
+	OK      bool
+	Message string
+}
+
+type UsersResponse struct {
+	Users   []UserInfo
+	OK      bool
+	Message string
+}
+
+type UserRequest struct {
+	Username string
+	FullName string
+	Role     string
+	Password string
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string
+	NewPassword string
+}
+
+type AccessResponse struct {
+	Access  database.DocumentAccess
+	OK      bool
+	Message string
+}
+
+type AccessRequest struct {
+	DocumentName string
+	Access       database.DocumentAccess
+}
+
+type CommentsRequest struct {
+	DocumentName string
+	Subsection   string
+	OpenOnly     bool
+}
+
+type CommentsResponse struct {
+	Comments []database.Comment
+	OK       bool
+	Message  string
+}
+
+type AddCommentRequest struct {
+	DocumentName string
+	Subsection   string
+	Item         int
+	ItemID       string
+	Start        int
+	End          int
+	Quote        string
+	Text         string
+}
+
+type CommentResponse struct {
+	Comment database.Comment
+	OK      bool
+	Message string
+}
+
+type CommentActionRequest struct {
+	DocumentName string
+	ID           int
+	Text         string
+}
+
+type WorkflowResponse struct {
+	Workflow database.Workflow
+	OK       bool
+	Message  string
+}
+
+type WorkflowRequest struct {
+	DocumentName string
+	Action       string
+	Comment      string
+}
+
+type SuggestionsRequest struct {
+	DocumentName string
+	Subsection   string
+	OpenOnly     bool
+}
+
+type SuggestionsResponse struct {
+	Suggestions []database.Suggestion
+	OK          bool
+	Message     string
+}
+
+type AddSuggestionRequest struct {
+	DocumentName string
+	Subsection   string
+	Item         int
+	NoOfItems    int
+	ContentType  []string
+	FileName     []string
+	Value        []string
+	Captions     []string
+	Landscape    []bool
+	Comment      string
+}
+
+type SuggestionResponse struct {
+	Suggestion database.Suggestion
+	OK         bool
+	Message    string
+}
+
+type SuggestionActionRequest struct {
+	DocumentName string
+	ID           int
+	Comment      string
+}
+
+type SuggestionDiffResponse struct {
+	Items    []database.ItemDiff
+	Outdated bool
+	OK       bool
+	Message  string
+}
+
+type RevisionsResponse struct {
+	Revisions []database.Revision
+	OK        bool
+	Message   string
+}
+
+type LockRequest struct {
+	DocumentName string
+	Subsection   string
+}
+
+type LockResponse struct {
+	Lock    database.SectionLock
+	OK      bool
+	Message string
+}
+
+type LocksResponse struct {
+	Locks   []database.SectionLock
+	OK      bool
+	Message string
+}
+
+type AuditRequest struct {
+	Actor        string
+	Action       string
+	DocumentName string
+	From         string
+	To           string
+	Offset       int
+	Limit        int
+}
+
+type AuditResponse struct {
+	Entries []database.AuditEntry
+	Total   int
+	OK      bool
+	Message string
+}
+
+type TrashResponse struct {
+	Trash   []database.TrashEntry
+	OK      bool
+	Message string
+}
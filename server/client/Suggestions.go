@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getSuggestions(c *gin.Context) {
+	var request SuggestionsRequest
+	var response SuggestionsResponse
+	response.Suggestions = make([]database.Suggestion, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection)
+	msg, suggestions, ok := database.GetSuggestions(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	for _, suggestion := range suggestions.Suggestions {
+		if request.Subsection != "" && suggestion.Subsection != request.Subsection {
+			continue
+		}
+		if request.OpenOnly && suggestion.Status != database.SuggestionOpen {
+			continue
+		}
+		response.Suggestions = append(response.Suggestions, suggestion)
+	}
+	response.OK = true
+	response.Message = "Suggestions Retrived"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addSuggestion(c *gin.Context) {
+	var request AddSuggestionRequest
+	var response SuggestionResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Subsection, request.Item)
+	var suggestion database.Suggestion
+	suggestion.Subsection = request.Subsection
+	suggestion.Item = request.Item
+	suggestion.Content.NoOfItems = request.NoOfItems
+	suggestion.Content.ContentType = append(make([]string, 0), request.ContentType...)
+	suggestion.Content.FileName = append(make([]string, 0), request.FileName...)
+	suggestion.Content.Value = append(make([]string, 0), request.Value...)
+	suggestion.Content.Captions = append(make([]string, 0), request.Captions...)
+	suggestion.Content.Landscape = append(make([]bool, 0), request.Landscape...)
+	suggestion.Comment = request.Comment
+	msg, suggestion, ok := database.AddSuggestion(request.DocumentName, suggestion, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Suggestion Added"
+	response.Suggestion = suggestion
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func getSuggestionDiff(c *gin.Context) {
+	var request SuggestionActionRequest
+	var response SuggestionDiffResponse
+	response.Items = make([]database.ItemDiff, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.ID)
+	msg, diff, ok := database.GetSuggestionDiff(request.DocumentName, request.ID, auth.GetUser(c))
+	response.Outdated = diff.Outdated
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Diff Retrived"
+	response.Items = diff.Items
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func acceptSuggestion(c *gin.Context) {
+	decideSuggestion(c, true)
+}
+
+func rejectSuggestion(c *gin.Context) {
+	decideSuggestion(c, false)
+}
+
+func decideSuggestion(c *gin.Context, accept bool) {
+	var request SuggestionActionRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.ID, accept)
+	msg, suggestion, ok := database.DecideSuggestion(request.DocumentName, request.ID, auth.GetUser(c), accept, request.Comment)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	if accept {
+		ack.Message = "Suggestion Accepted"
+		publish(c, events.ContentSaved, request.DocumentName, suggestion.Subsection, ack.Message)
+	} else {
+		ack.Message = "Suggestion Rejected"
+	}
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getRevisions(c *gin.Context) {
+	var addDocument AddDocument
+	var response RevisionsResponse
+	response.Revisions = make([]database.Revision, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, history, ok := database.GetRevisions(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Revisions Retrived"
+	response.Revisions = history.Revisions
+	c.IndentedJSON(http.StatusOK, response)
+}
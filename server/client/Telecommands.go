@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/tmtc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTelecommands(c *gin.Context) {
+	var addDocument AddDocument
+	var response TelecommandsResponse
+	response.Telecommands = make([]database.Telecommand, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, list, ok := database.GetTelecommands(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Telecommands Retrieved"
+	response.Telecommands = append(response.Telecommands, list.Telecommands...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addTelecommands(c *gin.Context) {
+	var request TelecommandsRequest
+	var response ValidationResponse
+	response.Problems = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	saveTelecommands(c, request.DocumentName, request.Telecommands, response)
+}
+
+func importTelecommands(c *gin.Context) {
+	var request ImportRequest
+	var response ValidationResponse
+	response.Problems = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Format)
+	data, err := base64.StdEncoding.DecodeString(request.Data)
+	if err != nil {
+		response.OK = false
+		response.Message = "File cannot be decoded"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	imported, err := tmtc.ImportTelecommands(data, request.Format)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot import Telecommands: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	telecommands := imported
+	if !request.Replace {
+		msg, existing, ok := database.GetTelecommands(request.DocumentName, auth.GetUser(c))
+		if !ok {
+			response.OK = false
+			response.Message = msg
+			c.IndentedJSON(http.StatusOK, response)
+			return
+		}
+		telecommands = tmtc.MergeTelecommands(existing.Telecommands, imported)
+	}
+	saveTelecommands(c, request.DocumentName, telecommands, response)
+}
+
+// saveTelecommands validates the list and stores it only if there are no
+// problems.
+func saveTelecommands(c *gin.Context, documentName string, telecommands []database.Telecommand, response ValidationResponse) {
+	response.Problems = tmtc.ValidateTelecommands(telecommands)
+	response.Count = len(telecommands)
+	if len(response.Problems) > 0 {
+		response.OK = false
+		response.Message = "Telecommands not saved, please correct the problems"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	var list database.TelecommandList
+	list.Telecommands = make([]database.Telecommand, 0)
+	list.Telecommands = append(list.Telecommands, telecommands...)
+	msg, ok := database.AddTelecommands(documentName, list, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Telecommands Added"
+	publish(c, events.ContentSaved, documentName, "Introduction-Telecommand", response.Message)
+	c.IndentedJSON(http.StatusOK, response)
+}
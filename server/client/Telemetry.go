@@ -0,0 +1,148 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/tmtc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTelemetry(c *gin.Context) {
+	var addDocument AddDocument
+	var response TelemetryResponse
+	response.Parameters = make([]database.TelemetryParameter, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, list, ok := database.GetTelemetry(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Telemetry Retrieved"
+	response.Parameters = append(response.Parameters, list.Parameters...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addTelemetry(c *gin.Context) {
+	var request TelemetryRequest
+	var response ValidationResponse
+	response.Problems = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	saveTelemetry(c, request.DocumentName, request.Parameters, response)
+}
+
+func importTelemetry(c *gin.Context) {
+	var request ImportRequest
+	var response ValidationResponse
+	response.Problems = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Format)
+	data, err := base64.StdEncoding.DecodeString(request.Data)
+	if err != nil {
+		response.OK = false
+		response.Message = "File cannot be decoded"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	imported, err := tmtc.ImportTelemetry(data, request.Format)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot import Telemetry: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	parameters := imported
+	if !request.Replace {
+		msg, existing, ok := database.GetTelemetry(request.DocumentName, auth.GetUser(c))
+		if !ok {
+			response.OK = false
+			response.Message = msg
+			c.IndentedJSON(http.StatusOK, response)
+			return
+		}
+		parameters = tmtc.MergeTelemetry(existing.Parameters, imported)
+	}
+	saveTelemetry(c, request.DocumentName, parameters, response)
+}
+
+// saveTelemetry validates the parameters and stores them only if there are
+// no problems.
+func saveTelemetry(c *gin.Context, documentName string, parameters []database.TelemetryParameter, response ValidationResponse) {
+	response.Problems = tmtc.ValidateTelemetry(parameters)
+	response.Count = len(parameters)
+	if len(response.Problems) > 0 {
+		response.OK = false
+		response.Message = "Telemetry not saved, please correct the problems"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	var list database.TelemetryList
+	list.Parameters = make([]database.TelemetryParameter, 0)
+	list.Parameters = append(list.Parameters, parameters...)
+	msg, ok := database.AddTelemetry(documentName, list, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Telemetry Added"
+	publish(c, events.ContentSaved, documentName, "Introduction-Telemetry", response.Message)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func exportTelemetry(c *gin.Context) {
+	var addDocument AddDocument
+	var response FileResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, list, ok := database.GetTelemetry(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	data, err := tmtc.ExportTelemetry(list.Parameters)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot export Telemetry: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Telemetry Exported"
+	response.FileName = addDocument.Name + "-telemetry.csv"
+	response.Content = base64.StdEncoding.EncodeToString(data)
+	c.IndentedJSON(http.StatusOK, response)
+}
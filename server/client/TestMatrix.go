@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestMatrixSettings(c *gin.Context) {
+	var addDocument AddDocument
+	var response TestMatrixSettingsResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, settings, ok := database.GetTestMatrixSettings(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Test Matrix Settings Retrieved"
+	response.Settings = settings
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addTestMatrixSettings(c *gin.Context) {
+	var request TestMatrixSettingsRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	if request.Settings.Phases == nil {
+		request.Settings.Phases = make([]string, 0)
+	}
+	if request.Settings.Overrides == nil {
+		request.Settings.Overrides = make([]database.TestMatrixOverride, 0)
+	}
+	msg, ok := database.AddTestMatrixSettings(request.DocumentName, request.Settings, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Test Matrix Settings Added"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getTestMatrix(c *gin.Context) {
+	var addDocument AddDocument
+	var response TestMatrixResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, matrix, ok := database.GetTestMatrix(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Test Matrix Retrieved"
+	response.Matrix = matrix
+	c.IndentedJSON(http.StatusOK, response)
+}
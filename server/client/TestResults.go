@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/health"
+	"intDocument/server/tmtc"
+	"intDocument/server/typst"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getTestResults(c *gin.Context) {
+	var addDocument AddDocument
+	var response TestResultsResponse
+	response.Results = make([]database.ProcedureResult, 0)
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, results, ok := database.GetTestResults(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Test Results Retrieved"
+	response.Results = append(response.Results, results.Results...)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addTestResult(c *gin.Context) {
+	var request TestResultRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Result.Procedure)
+	if request.Result.Steps == nil {
+		request.Result.Steps = make([]database.StepResult, 0)
+	}
+	msg, ok := database.AddProcedureResult(request.DocumentName, request.Result, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Test Result Added"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func compileTestReport(c *gin.Context) {
+	var addDocument AddDocument
+	var ack PDFResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, ok := health.CompileReady()
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	id := auth.GetWorkID(c)
+	msg, ok = typst.InitializeTestReport(id, addDocument.Name, auth.GetUser(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		ack.Content = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	data, ok := typst.Compile(id)
+	publishCompile(c, addDocument.Name, ok)
+	ack.Content = base64.StdEncoding.EncodeToString(data)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Compilation Successful"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+// uploadTelemetryLog evaluates a telemetry log of the checkout system against
+// a procedure and records the outcome as its test result.
+func uploadTelemetryLog(c *gin.Context) {
+	var request TelemetryLogRequest
+	var response LogEvaluationResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Procedure)
+	data, err := base64.StdEncoding.DecodeString(request.Data)
+	if err != nil {
+		response.OK = false
+		response.Message = "File cannot be decoded"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, procedures, ok := database.GetProcedures(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	var procedure *database.Procedure
+	for i := range procedures {
+		if procedures[i].Name == request.Procedure {
+			procedure = &procedures[i]
+			break
+		}
+	}
+	if procedure == nil {
+		response.OK = false
+		response.Message = "Procedure " + request.Procedure + " doesn't exist"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, tmList, ok := database.GetTelemetry(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	evaluation, err := tmtc.EvaluateLog(data, *procedure, tmList.Parameters)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot read Telemetry Log: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+
+	msg, results, ok := database.GetTestResults(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	result := database.ProcedureResult{Procedure: procedure.Name}
+	result.Steps = make([]database.StepResult, 0)
+	for _, existing := range results.Results {
+		if existing.Procedure == procedure.Name {
+			result = existing
+			break
+		}
+	}
+	if request.TestDate != "" {
+		result.TestDate = request.TestDate
+	}
+	if request.Operator != "" {
+		result.Operator = request.Operator
+	}
+	result = tmtc.ApplyEvaluation(result, evaluation)
+	msg, ok = database.AddProcedureResult(request.DocumentName, result, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = fmt.Sprintf("%d Samples, %d Steps evaluated, %d Samples out of limits", evaluation.Samples, len(evaluation.Steps), len(evaluation.OutOfLimits))
+	response.Evaluation = evaluation
+	c.IndentedJSON(http.StatusOK, response)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurgeExpiredDocuments purges the documents whose time in the trash is up.
+// It runs without a request, so it writes the audit entry and sends the
+// event for each document itself.
+func PurgeExpiredDocuments() {
+	msg, purged, ok := database.PurgeExpiredDocuments()
+	if !ok {
+		fmt.Println("Error when purging Trash", msg)
+	}
+	if len(purged) > 0 {
+		fmt.Println("Purged from Trash", purged)
+	}
+	for _, documentName := range purged {
+		var entry database.AuditEntry
+		entry.Time = time.Now().UTC().Format(time.RFC3339)
+		entry.Action = "/purgeDocument"
+		entry.DocumentName = documentName
+		entry.Summary = "Document Purged, its time in the Trash is up"
+		entry.OK = true
+		database.AddAuditEntry(entry)
+		var event events.Event
+		event.Type = events.DocumentPurged
+		event.DocumentName = documentName
+		event.Message = entry.Summary
+		events.Publish(event)
+	}
+}
+
+func getTrash(c *gin.Context) {
+	var response TrashResponse
+	fmt.Println("Request", auth.GetUser(c).Username, "Trash")
+	msg, trash, ok := database.GetTrash()
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.Trash = trash
+	response.OK = true
+	response.Message = "Trash Retrived"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func restoreDocument(c *gin.Context) {
+	var request AddDocument
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request Restore", auth.GetUser(c).Username, request.Name)
+	msg, ok := database.RestoreDocument(request.Name)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Document Restored"
+	publish(c, events.DocumentRestored, request.Name, "", "")
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func purgeDocument(c *gin.Context) {
+	var request AddDocument
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request Purge", auth.GetUser(c).Username, request.Name)
+	msg, ok := database.PurgeDocument(request.Name)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Document Purged"
+	publish(c, events.DocumentPurged, request.Name, "", "")
+	c.IndentedJSON(http.StatusOK, ack)
+}
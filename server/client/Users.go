@@ -0,0 +1,168 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/config"
+	"intDocument/server/database"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getUserInfo(user database.User) UserInfo {
+	return UserInfo{Username: user.Username, FullName: user.FullName, Role: user.Role}
+}
+
+func login(c *gin.Context) {
+	var request LoginRequest
+	var response LoginResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request Login", request.Username)
+	user, ok := database.CheckPassword(request.Username, request.Password)
+	if !ok {
+		response.OK = false
+		response.Message = "Invalid Username or Password"
+		c.IndentedJSON(http.StatusUnauthorized, response)
+		return
+	}
+	duration := time.Duration(config.Config.SessionHours) * time.Hour
+	msg, session, ok := database.AddSession(user.Username, duration)
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Logged In"
+	response.Token = session.Token
+	response.Username = user.Username
+	response.FullName = user.FullName
+	response.Role = user.Role
+	response.Expires = session.Expires.Format(time.RFC3339)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func logout(c *gin.Context) {
+	var ack Ack
+	fmt.Println("Request Logout", auth.GetUser(c).Username)
+	msg, ok := database.DeleteSession(auth.GetToken(c))
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Logged Out"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getCurrentUser(c *gin.Context) {
+	var response UserResponse
+	response.OK = true
+	response.Message = "User Retrived"
+	response.User = getUserInfo(auth.GetUser(c))
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func changePassword(c *gin.Context) {
+	var request ChangePasswordRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	user := auth.GetUser(c)
+	fmt.Println("Request Change Password", user.Username)
+	_, ok := database.CheckPassword(user.Username, request.OldPassword)
+	if !ok {
+		ack.OK = false
+		ack.Message = "Invalid Password"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	msg, ok := database.AddUser(user, request.NewPassword)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "Password Changed"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func getUsers(c *gin.Context) {
+	var response UsersResponse
+	response.Users = make([]UserInfo, 0)
+	fmt.Println("Request Users", auth.GetUser(c).Username)
+	msg, users, ok := database.GetAllUsers()
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, getUserInfo(user))
+	}
+	response.OK = true
+	response.Message = "Users Retrived"
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func addUser(c *gin.Context) {
+	var request UserRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request Add User", auth.GetUser(c).Username, request.Username, request.Role)
+	user := database.User{Username: request.Username, FullName: request.FullName, Role: request.Role}
+	msg, ok := database.AddUser(user, request.Password)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "User Added"
+	c.IndentedJSON(http.StatusOK, ack)
+}
+
+func deleteUser(c *gin.Context) {
+	var request UserRequest
+	var ack Ack
+	if err := c.BindJSON(&request); err != nil {
+		ack.OK = false
+		ack.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	fmt.Println("Request Delete User", auth.GetUser(c).Username, request.Username)
+	msg, ok := database.DeleteUser(request.Username)
+	if !ok {
+		ack.OK = false
+		ack.Message = msg
+		c.IndentedJSON(http.StatusOK, ack)
+		return
+	}
+	ack.OK = true
+	ack.Message = "User Deleted"
+	c.IndentedJSON(http.StatusOK, ack)
+}
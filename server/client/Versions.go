@@ -0,0 +1,42 @@
+package client
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sends the version of what was read or written as its ETag.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// versionRequired is the answer to a write that does not say which version
+// it is based on.
+const versionRequired = "Version Required, reload and save again"
+
+// getBaseVersion returns the version a write is based on, taken from the
+// If-Match header if there is one, otherwise from the Version of the body,
+// and false if the request has neither. Such a write would overwrite
+// whatever was saved since the client loaded it, so it is refused.
+func getBaseVersion(c *gin.Context, version *int) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if version == nil {
+			return 0, false
+		}
+		if *version < 0 {
+			return math.MinInt, true
+		}
+		return *version, true
+	}
+	header = strings.Trim(strings.TrimPrefix(header, "W/"), "\"")
+	value, err := strconv.Atoi(header)
+	if err != nil || value < 0 {
+		// matches no version, so the write is refused
+		return math.MinInt, true
+	}
+	return value, true
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func getWorkflow(c *gin.Context) {
+	var addDocument AddDocument
+	var response WorkflowResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, workflow, ok := database.GetWorkflow(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Workflow Retrived"
+	response.Workflow = workflow
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func transitionWorkflow(c *gin.Context) {
+	var request WorkflowRequest
+	var response WorkflowResponse
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName, request.Action)
+	msg, workflow, ok := database.Transition(request.DocumentName, request.Action, auth.GetUser(c), request.Comment)
+	response.Workflow = workflow
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "Document " + workflow.State
+	publish(c, events.DetailsChanged, request.DocumentName, "Workflow", response.Message)
+	c.IndentedJSON(http.StatusOK, response)
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"intDocument/server/auth"
+	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/tmtc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importXTCE reads the telecommands and telemetry parameters of an XTCE file
+// into the document. A list is only touched if the file defines entries for
+// it, and nothing is saved unless both lists validate.
+func importXTCE(c *gin.Context) {
+	var request ImportRequest
+	var response ValidationResponse
+	response.Problems = make([]string, 0)
+	response.Warnings = make([]string, 0)
+	if err := c.BindJSON(&request); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, request.DocumentName)
+	data, err := base64.StdEncoding.DecodeString(request.Data)
+	if err != nil {
+		response.OK = false
+		response.Message = "File cannot be decoded"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	importedTC, importedTM, warnings, err := tmtc.ImportXTCE(data)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot import XTCE: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	if len(importedTC) == 0 && len(importedTM) == 0 {
+		response.OK = false
+		response.Message = "XTCE file has no telecommands or telemetry parameters"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+
+	msg, tcList, ok := database.GetTelecommands(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, tmList, ok := database.GetTelemetry(request.DocumentName, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	if len(importedTC) > 0 {
+		if request.Replace {
+			tcList.Telecommands = importedTC
+		} else {
+			tcList.Telecommands = tmtc.MergeTelecommands(tcList.Telecommands, importedTC)
+		}
+	}
+	if len(importedTM) > 0 {
+		if request.Replace {
+			tmList.Parameters = importedTM
+		} else {
+			tmList.Parameters = tmtc.MergeTelemetry(tmList.Parameters, importedTM)
+		}
+	}
+
+	response.Count = len(importedTC) + len(importedTM)
+	response.Warnings = warnings
+	response.Problems = append(response.Problems, tmtc.ValidateTelecommands(tcList.Telecommands)...)
+	response.Problems = append(response.Problems, tmtc.ValidateTelemetry(tmList.Parameters)...)
+	if len(response.Problems) > 0 {
+		response.OK = false
+		response.Message = "XTCE not imported, please correct the problems"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, ok = database.AddTelecommands(request.DocumentName, tcList, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, ok = database.AddTelemetry(request.DocumentName, tmList, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = fmt.Sprintf("Imported %d Telecommands and %d Telemetry Parameters", len(importedTC), len(importedTM))
+	publish(c, events.ContentSaved, request.DocumentName, "Introduction-Telecommand", response.Message)
+	publish(c, events.ContentSaved, request.DocumentName, "Introduction-Telemetry", response.Message)
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func exportXTCE(c *gin.Context) {
+	var addDocument AddDocument
+	var response FileResponse
+	if err := c.BindJSON(&addDocument); err != nil {
+		response.OK = false
+		response.Message = "Bad Request"
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	fmt.Println("Request", auth.GetUser(c).Username, addDocument.Name)
+	msg, tcList, ok := database.GetTelecommands(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	msg, tmList, ok := database.GetTelemetry(addDocument.Name, auth.GetUser(c))
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	data, err := tmtc.ExportXTCE(addDocument.Name, tcList.Telecommands, tmList.Parameters)
+	if err != nil {
+		response.OK = false
+		response.Message = "Cannot export XTCE: " + err.Error()
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+	response.OK = true
+	response.Message = "XTCE Exported"
+	response.FileName = addDocument.Name + ".xml"
+	response.Content = base64.StdEncoding.EncodeToString(data)
+	c.IndentedJSON(http.StatusOK, response)
+}
@@ -15,6 +15,19 @@ type Configuration struct {
 	DeletePassword string `json:"DeletePassword"`
 	OllamaURL      string `json:"OllamaURL"`
 	OllamaModel    string `json:"OllamaModel"`
+	// Local Typst package and font directories, so that compiling does not
+	// need network access or system fonts.
+	TypstPackagePath string `json:"TypstPackagePath"`
+	TypstFontPath    string `json:"TypstFontPath"`
+	// Password of the "admin" user created when the database has no users,
+	// DeletePassword is used if it is empty, and a generated password printed
+	// at startup if that is shorter than 8 characters.
+	AdminPassword string `json:"AdminPassword"`
+	SessionHours  int    `json:"SessionHours"`
+	// Minutes a section lock lasts without a heartbeat.
+	LockMinutes int `json:"LockMinutes"`
+	// Days a deleted document stays in the trash before it is purged.
+	TrashDays int `json:"TrashDays"`
 }
 
 // Global Config variable
@@ -42,6 +55,33 @@ func ReadConfiguration(path string) error {
 		Config.OllamaModel = "llama3"
 	}
 
-	fmt.Printf("Config: %+v\n ", Config)
+	if Config.TypstPackagePath == "" {
+		Config.TypstPackagePath = "resources/typst/packages"
+	}
+
+	if Config.TypstFontPath == "" {
+		Config.TypstFontPath = "resources/typst/fonts"
+	}
+
+	if Config.AdminPassword == "" {
+		Config.AdminPassword = Config.DeletePassword
+	}
+
+	if Config.SessionHours <= 0 {
+		Config.SessionHours = 12
+	}
+
+	if Config.LockMinutes <= 0 {
+		Config.LockMinutes = 5
+	}
+
+	if Config.TrashDays <= 0 {
+		Config.TrashDays = 30
+	}
+
+	printable := Config
+	printable.DeletePassword = "****"
+	printable.AdminPassword = "****"
+	fmt.Printf("Config: %+v\n ", printable)
 	return nil
 }
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Access levels checked by CheckAccess.
+const (
+	AccessView = iota
+	AccessEdit
+	AccessOwner
+)
+
+func getDefaultAccess() DocumentAccess {
+	var access DocumentAccess
+	access.Editors = make([]string, 0)
+	access.Viewers = make([]string, 0)
+	return access
+}
+
+// GetAccess returns the access list of the document. Documents created
+// before access lists existed have no owner.
+func GetAccess(documentName string, user User) (string, DocumentAccess, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultAccess(), false
+	}
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, getDefaultAccess(), false
+	}
+	return getAccess(documentName)
+}
+
+// getAccess reads the access list of a document that exists, in the trash
+// or not.
+func getAccess(documentName string) (string, DocumentAccess, bool) {
+	access := getDefaultAccess()
+	c := db.Collection(documentName)
+	if !c.Has("Access") {
+		return "", access, true
+	}
+	err := c.Get("Access", &access)
+	if err != nil {
+		return err.Error(), access, false
+	}
+	return "", access, true
+}
+
+// AddAccess replaces the access list of the document, which only its owner
+// or an admin may do.
+func AddAccess(documentName string, access DocumentAccess, user User) (string, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessOwner); !ok {
+		return errMsg, false
+	}
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if access.Editors == nil {
+		access.Editors = make([]string, 0)
+	}
+	if access.Viewers == nil {
+		access.Viewers = make([]string, 0)
+	}
+	if access.Owner == "" {
+		return "Owner is empty", false
+	}
+	usernames := append([]string{access.Owner}, access.Editors...)
+	usernames = append(usernames, access.Viewers...)
+	for _, username := range usernames {
+		_, _, ok := GetUser(username)
+		if !ok {
+			return "Unknown user " + username, false
+		}
+	}
+	err := c.Add("Access", access)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// CheckAccess reports whether the user may view, edit or administer the
+// access list of the document. Admins may do everything. The owner and the
+// editors may edit. Everybody may view a document unless it is restricted,
+// in which case only the owner, editors and viewers may. A document without
+// an owner may be edited by everybody and only admins may change its access
+// list. Nobody has access to a document that does not exist. A document in
+// the trash keeps its list, so that its readers hear that it was deleted.
+//
+// The exported functions on a document take the user and check access
+// first, views for reading and reviewing, edits for changes. The unexported
+// forms they wrap are for use within the package, on behalf of a function
+// that has already checked.
+func CheckAccess(documentName string, user User, level int) (string, bool) {
+	if !DocumentExists(documentName) {
+		return "Document Doesn't Exist", false
+	}
+	if user.Role == RoleAdmin {
+		return "", true
+	}
+	errMsg, access, ok := getAccess(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	isOwner := access.Owner != "" && access.Owner == user.Username
+	isEditor := isOwner || slices.Contains(access.Editors, user.Username)
+	switch level {
+	case AccessOwner:
+		if !isOwner {
+			return "Only the owner can change access to " + documentName, false
+		}
+	case AccessEdit:
+		if !isEditor && access.Owner != "" {
+			return "No edit access to " + documentName, false
+		}
+	default:
+		if access.Restricted && !isEditor && !slices.Contains(access.Viewers, user.Username) {
+			return "No access to " + documentName, false
+		}
+	}
+	return "", true
+}
+
+// GetVisibleDocumentNames returns the names of the documents the user may
+// view.
+func GetVisibleDocumentNames(user User) ([]string, bool) {
+	visible := make([]string, 0)
+	documentNames, ok := GetAllDocumentNames()
+	if !ok {
+		return visible, false
+	}
+	for _, name := range documentNames {
+		_, ok := CheckAccess(name, user, AccessView)
+		if ok {
+			visible = append(visible, name)
+		}
+	}
+	return visible, true
+}
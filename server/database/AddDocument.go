@@ -7,8 +7,14 @@ import (
 	"go.mills.io/bitcask/v2"
 )
 
-func AddDocument(documentName string) (string, bool) {
+func AddDocument(documentName string, owner string) (string, bool) {
+	if IsReservedName(documentName) {
+		return "Document Name cannot start with '_'", false
+	}
 	c := db.Collection(documentName)
+	if IsTrashed(documentName) {
+		return "A Document of this Name is in the Trash", false
+	}
 	if c.Exists() {
 		return "Duplicate Document Name", false
 	}
@@ -23,6 +29,16 @@ func AddDocument(documentName string) (string, bool) {
 	if err != nil {
 		return err.Error(), false
 	}
+	err = c.Add("DistributionList", getDefaultDistributionList())
+	if err != nil {
+		return err.Error(), false
+	}
+	access := getDefaultAccess()
+	access.Owner = owner
+	err = c.Add("Access", access)
+	if err != nil {
+		return err.Error(), false
+	}
 	var subsectionNames = make([]string, 0)
 	subsectionNames = append(subsectionNames, "Introduction-Acronyms")
 	subsectionNames = append(subsectionNames, "Introduction-SSIntroduction")
@@ -46,12 +62,7 @@ func AddDocument(documentName string) (string, bool) {
 		return errMsg, false
 	}
 
-	l := db.List(bitcask.Key("documentNames"))
-	err = l.Append(bitcask.Value(documentName))
-	if err != nil {
-		return err.Error(), false
-	}
-	return "", true
+	return addToIndex(documentName)
 }
 
 func addEmptyContent(sectionNames []string, c *bitcask.Collection) (string, bool) {
@@ -73,55 +84,176 @@ func addEmptyContent(sectionNames []string, c *bitcask.Collection) (string, bool
 
 }
 
-func AddDocumentDetails(documentName string, documentDetails DocumentDetails) (string, bool) {
+func AddDocumentDetails(documentName string, documentDetails DocumentDetails, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	return addDocumentDetails(documentName, documentDetails)
+}
+
+// UpdateDocumentDetails stores the details if they are still at the given
+// version and returns the new version.
+func UpdateDocumentDetails(documentName string, documentDetails DocumentDetails, version int, user User) (string, int, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, 0, false
+	}
+	current := GetVersion(documentName, "DocumentDetails")
+	if current != version {
+		return VersionConflict, current, false
+	}
+	errMsg, ok := addDocumentDetails(documentName, documentDetails)
+	return errMsg, GetVersion(documentName, "DocumentDetails"), ok
+}
+
+func addDocumentDetails(documentName string, documentDetails DocumentDetails) (string, bool) {
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	language := strings.ToLower(documentDetails.Language)
+	if language != "" && language != "en" && language != "hi" && language != "bilingual" {
+		return "Language must be en, hi or bilingual", false
 	}
 	err := c.Add("DocumentDetails", documentDetails)
 	if err != nil {
 		return err.Error(), false
 	}
-	return "", true
+	return increaseVersion(documentName, "DocumentDetails")
+}
+
+func AddSubsystemDetails(documentName string, subsystemDetails SubsystemDetails, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	return addSubsystemDetails(documentName, subsystemDetails)
+}
+
+// UpdateSubsystemDetails stores the details if they are still at the given
+// version and returns the new version.
+func UpdateSubsystemDetails(documentName string, subsystemDetails SubsystemDetails, version int, user User) (string, int, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, 0, false
+	}
+	current := GetVersion(documentName, "SubsystemDetails")
+	if current != version {
+		return VersionConflict, current, false
+	}
+	errMsg, ok := addSubsystemDetails(documentName, subsystemDetails)
+	return errMsg, GetVersion(documentName, "SubsystemDetails"), ok
 }
 
-func AddSubsystemDetails(documentName string, subsystemDetails SubsystemDetails) (string, bool) {
+func addSubsystemDetails(documentName string, subsystemDetails SubsystemDetails) (string, bool) {
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
 	}
 	err := c.Add("SubsystemDetails", subsystemDetails)
 	if err != nil {
 		fmt.Println(err.Error())
 		return err.Error(), false
 	}
-	return "", true
+	return increaseVersion(documentName, "SubsystemDetails")
 }
 
-func AddContent(documentName string, subsection string, content Content) (string, bool) {
+// AddContent stores the content of the subsection for the user, unless
+// another user holds its lock.
+func AddContent(documentName string, subsection string, content Content, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, ok = checkLock(documentName, subsection, user.Username)
+	if !ok {
+		return errMsg, false
+	}
+	return addContent(documentName, subsection, content)
+}
+
+// UpdateContent stores the content of the subsection for the user if it is
+// still at the given version and returns the new version.
+// Another user's lock on the subsection refuses the write.
+func UpdateContent(documentName string, subsection string, content Content, version int, user User) (string, int, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, 0, false
+	}
+	return updateContent(documentName, subsection, content, version, user.Username)
+}
+
+// updateContent is UpdateContent for callers that hold writeMutex.
+func updateContent(documentName string, subsection string, content Content, version int, username string) (string, int, bool) {
+	current := GetVersion(documentName, subsection)
+	errMsg, ok := checkLock(documentName, subsection, username)
+	if !ok {
+		return errMsg, current, false
+	}
+	if current != version {
+		return VersionConflict, current, false
+	}
+	errMsg, ok = addContent(documentName, subsection, content)
+	return errMsg, GetVersion(documentName, subsection), ok
+}
+
+func addContent(documentName string, subsection string, content Content) (string, bool) {
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
 	}
+	_, previous, _ := getContent(documentName, subsection)
+	content = assignItemIDs(previous, content)
 	err := c.Add(subsection, content)
 	if err != nil {
 		fmt.Println(err.Error())
 		return err.Error(), false
 	}
-	return "", true
+	return increaseVersion(documentName, subsection)
 }
 
-func CopyDocument(documentName string, newDocumentName string) (string, bool) {
+// CopyDocument copies a document the owner may view under a new name owned
+// by the owner. The editors, viewers and restriction of the old document are
+// kept.
+func CopyDocument(documentName string, newDocumentName string, owner User) (string, bool) {
+	if errMsg, ok := CheckAccess(documentName, owner, AccessView); !ok {
+		return errMsg, false
+	}
+	if IsReservedName(newDocumentName) {
+		return "Document Name cannot start with '_'", false
+	}
 	c := db.Collection(newDocumentName)
+	if IsTrashed(newDocumentName) {
+		return "A Document of this Name is in the Trash", false
+	}
 	if c.Exists() {
 		return "Duplicate Document Name", false
 	}
-	cOld := db.Collection(documentName)
-	if !cOld.Exists() {
-		return "Duplicate Doesn't Exist", false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
 	}
 
-	_, documentDetails, ok := GetDocumentDetails(documentName)
+	_, documentDetails, ok := getDocumentDetails(documentName)
 	if !ok {
 		return "Problem with old Document", false
 	}
@@ -130,7 +262,7 @@ func CopyDocument(documentName string, newDocumentName string) (string, bool) {
 		return err.Error(), false
 	}
 
-	_, subsystemDetails, ok := GetSubsystemDetails(documentName)
+	_, subsystemDetails, ok := getSubsystemDetails(documentName)
 	if !ok {
 		return "Problem with old Document", false
 	}
@@ -138,6 +270,72 @@ func CopyDocument(documentName string, newDocumentName string) (string, bool) {
 	if err != nil {
 		return err.Error(), false
 	}
+
+	_, distributionList, ok := getDistributionList(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	err = c.Add("DistributionList", distributionList)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	_, layout, ok := getLayout(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	err = c.Add("Layout", layout)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	_, telecommands, ok := getTelecommands(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	err = c.Add("Introduction-TelecommandList", telecommands)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	_, telemetry, ok := getTelemetry(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	err = c.Add("Introduction-TelemetryList", telemetry)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	_, testMatrixSettings, ok := getTestMatrixSettings(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	err = c.Add("TestMatrixSettings", testMatrixSettings)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	_, access, ok := getAccess(documentName)
+	if !ok {
+		return "Problem with old Document", false
+	}
+	access.Owner = owner.Username
+	err = c.Add("Access", access)
+	if err != nil {
+		return err.Error(), false
+	}
+
+	for _, section := range RequirementSections {
+		_, requirements, ok := getRequirements(documentName, section)
+		if !ok {
+			return "Problem with old Document", false
+		}
+		err = c.Add(section+"List", requirements)
+		if err != nil {
+			return err.Error(), false
+		}
+	}
 	var subsectionNames = make([]string, 0)
 	subsectionNames = append(subsectionNames, "Introduction-Acronyms")
 	subsectionNames = append(subsectionNames, "Introduction-SSIntroduction")
@@ -157,17 +355,12 @@ func CopyDocument(documentName string, newDocumentName string) (string, bool) {
 	subsectionNames = append(subsectionNames, "Annexure-TestResultsFormat")
 
 	copyContent(documentName, subsectionNames, c)
-	l := db.List(bitcask.Key("documentNames"))
-	err = l.Append(bitcask.Value(newDocumentName))
-	if err != nil {
-		return err.Error(), false
-	}
-	return "", true
+	return addToIndex(newDocumentName)
 }
 
 func copyContent(documentName string, sectionNames []string, c *bitcask.Collection) (string, bool) {
 	for i := 0; i < len(sectionNames); i++ {
-		_, content, ok := GetContent(documentName, sectionNames[i])
+		_, content, ok := getContent(documentName, sectionNames[i])
 		if !ok {
 			return "Problem with old Document", false
 		}
@@ -178,41 +371,3 @@ func copyContent(documentName string, sectionNames []string, c *bitcask.Collecti
 	}
 	return "", true
 }
-
-func DeleteDocument(documentName string) (string, bool) {
-	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", false
-	}
-	err := c.Drop()
-	if err != nil {
-		return "Document Can't be deleted", false
-	}
-	db.Sync()
-	l := db.List(bitcask.Key("documentNames"))
-	length, err := l.Len()
-	if err != nil {
-		fmt.Println("Error when reading Document Names", err.Error())
-		return "Error When reading Document Names", false
-	}
-	var newNames = make([]string, 0)
-	for i := 0; i < int(length); i++ {
-		data, err := l.Index(int64(i))
-		if err != nil {
-			return "Cannot read Document Names", false
-		}
-		name := string(data)
-		if len(strings.TrimSpace(name)) > 0 && name != documentName {
-			newNames = append(newNames, name)
-		}
-	}
-	for i := 0; i < int(length); i++ {
-		l.Pop()
-	}
-	db.Sync()
-	for i := 0; i < len(newNames); i++ {
-		l.Append(bitcask.Value(newNames[i]))
-	}
-	db.Sync()
-	return "", true
-}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.mills.io/bitcask/v2"
+)
+
+// The audit log is a list that is only ever appended to.
+const auditKey = "_auditLog"
+
+var auditMutex sync.Mutex
+
+func AddAuditEntry(entry AuditEntry) (string, bool) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err.Error(), false
+	}
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	l := db.List(bitcask.Key(auditKey))
+	err = l.Append(bitcask.Value(data))
+	if err != nil {
+		fmt.Println("Error when writing Audit Log", err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+func matchesAuditFilter(entry AuditEntry, filter AuditFilter) bool {
+	if filter.Actor != "" && entry.Actor != filter.Actor {
+		return false
+	}
+	if filter.Action != "" && !strings.EqualFold(strings.TrimPrefix(entry.Action, "/"), strings.TrimPrefix(filter.Action, "/")) {
+		return false
+	}
+	if filter.DocumentName != "" && entry.DocumentName != filter.DocumentName {
+		return false
+	}
+	// Times are RFC 3339 in UTC, so they compare as strings. A date alone
+	// as To includes the whole day.
+	if filter.From != "" && entry.Time < filter.From {
+		return false
+	}
+	if filter.To != "" && entry.Time > filter.To && !strings.HasPrefix(entry.Time, filter.To) {
+		return false
+	}
+	return true
+}
+
+// GetAuditEntries returns the entries matching the filter, oldest first,
+// with the total number of matches. Offset and Limit page through the
+// matches, a Limit of 0 returns all of them.
+func GetAuditEntries(filter AuditFilter) (string, []AuditEntry, int, bool) {
+	entries := make([]AuditEntry, 0)
+	l := db.List(bitcask.Key(auditKey))
+	length, err := l.Len()
+	if err != nil {
+		fmt.Println("Error when reading Audit Log", err.Error())
+		return err.Error(), entries, 0, false
+	}
+	total := 0
+	var i int64
+	for i = 0; i < length; i++ {
+		data, err := l.Index(i)
+		if err != nil {
+			return err.Error(), entries, total, false
+		}
+		var entry AuditEntry
+		err = json.Unmarshal(data, &entry)
+		if err != nil {
+			return err.Error(), entries, total, false
+		}
+		if !matchesAuditFilter(entry, filter) {
+			continue
+		}
+		if total >= filter.Offset && (filter.Limit <= 0 || len(entries) < filter.Limit) {
+			entries = append(entries, entry)
+		}
+		total = total + 1
+	}
+	return "", entries, total, true
+}
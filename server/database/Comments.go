@@ -0,0 +1,207 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// timeFormat is used for the times recorded with review activity.
+const timeFormat = "2006-01-02 15:04"
+
+func getDefaultComments() CommentList {
+	var comments CommentList
+	comments.NextID = 1
+	comments.Comments = make([]Comment, 0)
+	return comments
+}
+
+func GetComments(documentName string, user User) (string, CommentList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultComments(), false
+	}
+	return getComments(documentName)
+}
+
+func getComments(documentName string) (string, CommentList, bool) {
+	comments := getDefaultComments()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, comments, false
+	}
+	if !c.Has("Comments") {
+		return "", comments, true
+	}
+	err := c.Get("Comments", &comments)
+	if err != nil {
+		return err.Error(), comments, false
+	}
+	return "", locateComments(documentName, comments), true
+}
+
+// GetOpenComments returns the unresolved comments of the document, of one
+// subsection if subsection is not empty.
+func GetOpenComments(documentName string, subsection string, user User) (string, []Comment, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, nil, false
+	}
+	return getOpenComments(documentName, subsection)
+}
+
+func getOpenComments(documentName string, subsection string) (string, []Comment, bool) {
+	open := make([]Comment, 0)
+	errMsg, comments, ok := getComments(documentName)
+	if !ok {
+		return errMsg, open, false
+	}
+	for _, comment := range comments.Comments {
+		if !comment.Resolved && (subsection == "" || comment.Subsection == subsection) {
+			open = append(open, comment)
+		}
+	}
+	return "", open, true
+}
+
+func saveComments(documentName string, comments CommentList) (string, bool) {
+	c := db.Collection(documentName)
+	err := c.Add("Comments", comments)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// AddComment starts a new thread by the user on an item of a subsection and
+// returns it with its ID.
+func AddComment(documentName string, comment Comment, user User) (string, Comment, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessView)
+	if !ok {
+		return errMsg, comment, false
+	}
+	errMsg, comments, ok := getComments(documentName)
+	if !ok {
+		return errMsg, comment, false
+	}
+	if strings.TrimSpace(comment.Text) == "" {
+		return "Comment is empty", comment, false
+	}
+	c := db.Collection(documentName)
+	if !c.Has(comment.Subsection) {
+		return "Unknown Subsection " + comment.Subsection, comment, false
+	}
+	errMsg, content, ok := getContent(documentName, comment.Subsection)
+	if !ok {
+		return errMsg, comment, false
+	}
+	if comment.ItemID != "" {
+		comment.Item = slices.Index(content.ItemIDs, comment.ItemID)
+		if comment.Item < 0 {
+			return "Item " + comment.ItemID + " does not exist in " + comment.Subsection, comment, false
+		}
+	}
+	if comment.Item < 0 || comment.Item >= content.NoOfItems {
+		return fmt.Sprintf("Item %d does not exist in %s", comment.Item+1, comment.Subsection), comment, false
+	}
+	comment.ItemID = content.ItemIDs[comment.Item]
+	if comment.Start < 0 || comment.End < comment.Start {
+		return "Invalid text range", comment, false
+	}
+	comment.ID = comments.NextID
+	comment.Author = user.Username
+	comment.Time = time.Now().Format(timeFormat)
+	comment.Replies = make([]CommentReply, 0)
+	comment.Resolved = false
+	comment.ResolvedBy = ""
+	comment.ResolvedTime = ""
+	comments.NextID = comments.NextID + 1
+	comments.Comments = append(comments.Comments, comment)
+	errMsg, ok = saveComments(documentName, comments)
+	return errMsg, comment, ok
+}
+
+func findComment(comments CommentList, id int) int {
+	for i, comment := range comments.Comments {
+		if comment.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddReply adds a reply by the user to a thread. Replying to a resolved
+// thread does not reopen it. The thread is returned with the reply.
+func AddReply(documentName string, id int, reply CommentReply, user User) (string, Comment, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessView)
+	if !ok {
+		return errMsg, Comment{}, false
+	}
+	errMsg, comments, ok := getComments(documentName)
+	if !ok {
+		return errMsg, Comment{}, false
+	}
+	i := findComment(comments, id)
+	if i < 0 {
+		return "Comment Doesn't Exist", Comment{}, false
+	}
+	if strings.TrimSpace(reply.Text) == "" {
+		return "Reply is empty", Comment{}, false
+	}
+	reply.Author = user.Username
+	reply.Time = time.Now().Format(timeFormat)
+	comments.Comments[i].Replies = append(comments.Comments[i].Replies, reply)
+	errMsg, ok = saveComments(documentName, comments)
+	return errMsg, comments.Comments[i], ok
+}
+
+// mayResolve reports whether the user may resolve or reopen the comment:
+// its author, an author who may edit the document, a reviewer or an
+// approver.
+func mayResolve(documentName string, comment Comment, user User) bool {
+	if comment.Author == user.Username || hasRole(user, RoleReviewer) || hasRole(user, RoleApprover) {
+		return true
+	}
+	_, isEditor := CheckAccess(documentName, user, AccessEdit)
+	return isEditor && hasRole(user, RoleAuthor)
+}
+
+// ResolveComment resolves or reopens a thread for the user, see mayResolve.
+func ResolveComment(documentName string, id int, user User, resolved bool) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessView)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, comments, ok := getComments(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	i := findComment(comments, id)
+	if i < 0 {
+		return "Comment Doesn't Exist", false
+	}
+	if !mayResolve(documentName, comments.Comments[i], user) {
+		return "Only the author of the comment, an editor of the document, a reviewer or an approver may resolve it", false
+	}
+	if comments.Comments[i].Resolved == resolved {
+		if resolved {
+			return "Comment is already resolved", false
+		}
+		return "Comment is already open", false
+	}
+	comments.Comments[i].Resolved = resolved
+	if resolved {
+		comments.Comments[i].ResolvedBy = user.Username
+		comments.Comments[i].ResolvedTime = time.Now().Format(timeFormat)
+	} else {
+		comments.Comments[i].ResolvedBy = ""
+		comments.Comments[i].ResolvedTime = ""
+	}
+	return saveComments(documentName, comments)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"intDocument/server/config"
+	"time"
 
 	"go.mills.io/bitcask/v2"
 )
@@ -21,3 +22,21 @@ func Connect() (string, bool) {
 	}
 	return "", true
 }
+
+// CheckWritable writes and removes a marker key to confirm that the database
+// accepts writes.
+func CheckWritable() (string, bool) {
+	if db == nil {
+		return "Database is not open", false
+	}
+	key := bitcask.Key("healthCheck")
+	err := db.Put(key, bitcask.Value(time.Now().Format(time.RFC3339)))
+	if err != nil {
+		return "Database is not writable: " + err.Error(), false
+	}
+	err = db.Delete(key)
+	if err != nil {
+		return "Database is not writable: " + err.Error(), false
+	}
+	return "", true
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"strings"
+)
+
+// textContentTypes are the content types whose value is plain text. Other
+// values are encoded files and are only compared as a whole.
+var textContentTypes = []string{"text", "code", "table", "procedure"}
+
+// splitLines returns the lines of a text, none for an empty text.
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines returns the line diff of two texts from their longest common
+// subsequence. Op is "=" for kept lines, "-" for removed and "+" for added.
+// An empty text has no lines, so a text compared with an empty one is all
+// added or all removed.
+func diffLines(old string, new string) []DiffLine {
+	a := splitLines(old)
+	b := splitLines(new)
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	lines := make([]DiffLine, 0)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			lines = append(lines, DiffLine{Op: "=", Text: a[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			lines = append(lines, DiffLine{Op: "-", Text: a[i]})
+			i++
+		} else {
+			lines = append(lines, DiffLine{Op: "+", Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{Op: "-", Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{Op: "+", Text: b[j]})
+	}
+	return lines
+}
+
+func markLines(op string, text string) []DiffLine {
+	lines := make([]DiffLine, 0)
+	for _, line := range strings.Split(text, "\n") {
+		lines = append(lines, DiffLine{Op: op, Text: line})
+	}
+	return lines
+}
+
+// describeItem returns the lines shown for an item in a diff. Files are
+// shown by type and name only.
+func describeItem(content Content, i int) string {
+	contentType := strings.ToLower(content.ContentType[i])
+	text := ""
+	for _, textType := range textContentTypes {
+		if contentType == textType {
+			text = content.Value[i]
+		}
+	}
+	if text == "" {
+		text = "[" + content.ContentType[i] + " " + content.FileName[i] + "]"
+	}
+	if strings.TrimSpace(content.Captions[i]) != "" {
+		text = text + "\n" + "Caption: " + content.Captions[i]
+	}
+	return text
+}
+
+// diffContent compares two contents item by item. Unchanged items are left
+// out. offset is added to the item numbers.
+func diffContent(old Content, new Content, offset int) []ItemDiff {
+	diffs := make([]ItemDiff, 0)
+	for i := 0; i < max(old.NoOfItems, new.NoOfItems); i++ {
+		var oldText, newText string
+		if i < old.NoOfItems {
+			oldText = describeItem(old, i)
+		}
+		if i < new.NoOfItems {
+			newText = describeItem(new, i)
+		}
+		changed := oldText != newText
+		if !changed && i < old.NoOfItems && i < new.NoOfItems {
+			changed = old.Value[i] != new.Value[i] || old.Landscape[i] != new.Landscape[i]
+		}
+		if !changed {
+			continue
+		}
+		var diff ItemDiff
+		diff.Item = i + offset
+		if i >= old.NoOfItems {
+			diff.Lines = markLines("+", newText)
+		} else if i >= new.NoOfItems {
+			diff.Lines = markLines("-", oldText)
+		} else {
+			diff.Lines = diffLines(oldText, newText)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
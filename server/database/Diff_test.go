@@ -0,0 +1,61 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []DiffLine
+	}{
+		{"equal", "a\nb", "a\nb", []DiffLine{{"=", "a"}, {"=", "b"}}},
+		{"added", "a\nc", "a\nb\nc", []DiffLine{{"=", "a"}, {"+", "b"}, {"=", "c"}}},
+		{"removed", "a\nb\nc", "a\nc", []DiffLine{{"=", "a"}, {"-", "b"}, {"=", "c"}}},
+		{"changed", "a\nb\nc", "a\nB\nc", []DiffLine{{"=", "a"}, {"-", "b"}, {"+", "B"}, {"=", "c"}}},
+		{"appended", "a", "a\nb\nc", []DiffLine{{"=", "a"}, {"+", "b"}, {"+", "c"}}},
+		{"truncated", "a\nb\nc", "a", []DiffLine{{"=", "a"}, {"-", "b"}, {"-", "c"}}},
+		{"from empty", "", "a", []DiffLine{{"+", "a"}}},
+		{"to empty", "a\nb", "", []DiffLine{{"-", "a"}, {"-", "b"}}},
+		{"both empty", "", "", []DiffLine{}},
+		{"replaced", "x\ny", "p\nq", []DiffLine{{"-", "x"}, {"-", "y"}, {"+", "p"}, {"+", "q"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := diffLines(test.old, test.new)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("diffLines(%q, %q) = %v, want %v", test.old, test.new, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffContent(t *testing.T) {
+	before := Content{
+		NoOfItems:   2,
+		ContentType: []string{"Text", "Image"},
+		FileName:    []string{"", "block.png"},
+		Value:       []string{"one\ntwo", "AAAA"},
+		Captions:    []string{"", "Block Diagram"},
+		Landscape:   []bool{false, false},
+	}
+	after := Content{
+		NoOfItems:   3,
+		ContentType: []string{"Text", "Image", "Text"},
+		FileName:    []string{"", "block.png", ""},
+		Value:       []string{"one\ntwo", "BBBB", "three"},
+		Captions:    []string{"", "Block Diagram", ""},
+		Landscape:   []bool{false, false, false},
+	}
+	want := []ItemDiff{
+		{Item: 11, Lines: []DiffLine{{"=", "[Image block.png]"}, {"=", "Caption: Block Diagram"}}},
+		{Item: 12, Lines: []DiffLine{{"+", "three"}}},
+	}
+	got := diffContent(before, after, 10)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffContent() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func getDefaultDistributionList() DistributionList {
+	var list DistributionList
+	list.Entries = make([]DistributionEntry, 0)
+	list.Entries = append(list.Entries, DistributionEntry{CopyNo: 1, IssuedTo: "ISO Record", Medium: "Softcopy"})
+	list.Entries = append(list.Entries, DistributionEntry{CopyNo: 2, IssuedTo: "Master Copy Originator (Uncontrolled)", Medium: "Softcopy"})
+	list.Entries = append(list.Entries, DistributionEntry{CopyNo: 3, IssuedTo: "Committee Members", Medium: "Softcopy"})
+	return list
+}
+
+// GetDistributionList returns the distribution list of the document. Documents
+// created before the list was stored get the standard three copies.
+func GetDistributionList(documentName string, user User) (string, DistributionList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultDistributionList(), false
+	}
+	return getDistributionList(documentName)
+}
+
+func getDistributionList(documentName string) (string, DistributionList, bool) {
+	list := DistributionList{}
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, list, false
+	}
+	if !c.Has("DistributionList") {
+		return "", getDefaultDistributionList(), true
+	}
+	err := c.Get("DistributionList", &list)
+	if err != nil {
+		return err.Error(), list, false
+	}
+	if list.Entries == nil {
+		list.Entries = make([]DistributionEntry, 0)
+	}
+	return "", list, true
+}
+
+func AddDistributionList(documentName string, list DistributionList, user User) (string, bool) {
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	copyNos := make(map[int]bool)
+	for i, entry := range list.Entries {
+		if entry.CopyNo <= 0 {
+			return "Invalid Copy No in row " + strconv.Itoa(i+1), false
+		}
+		if copyNos[entry.CopyNo] {
+			return "Duplicate Copy No " + strconv.Itoa(entry.CopyNo), false
+		}
+		copyNos[entry.CopyNo] = true
+		if len(strings.TrimSpace(entry.IssuedTo)) == 0 {
+			return "Issued To is empty for Copy No " + strconv.Itoa(entry.CopyNo), false
+		}
+	}
+	err := c.Add("DistributionList", list)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
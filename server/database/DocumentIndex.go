@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.mills.io/bitcask/v2"
+)
+
+// The names of the documents and the trash are kept under a single key, so
+// that every change to them is one atomic write.
+const indexCollection = "_documents"
+const indexKey = "Index"
+
+// Older databases kept the names in this list. It is read once to build the
+// index and not written to any more.
+const legacyIndexKey = "documentNames"
+
+// indexMutex guards the document index. Whoever needs writeMutex as well,
+// like the writes that check the trash or a purge, takes writeMutex first.
+var indexMutex sync.Mutex
+
+// getIndex returns the document index, building it from the old list of
+// document names if there is none yet. The caller holds indexMutex.
+func getIndex() (string, DocumentIndex, bool) {
+	index := DocumentIndex{Names: make([]string, 0), Trash: make([]TrashEntry, 0)}
+	c := db.Collection(indexCollection)
+	if c.Has(indexKey) {
+		err := c.Get(indexKey, &index)
+		if err != nil {
+			return err.Error(), index, false
+		}
+		return "", index, true
+	}
+	l := db.List(bitcask.Key(legacyIndexKey))
+	length, err := l.Len()
+	if err != nil {
+		fmt.Println("Error when reading Document Names", err.Error())
+		return err.Error(), index, false
+	}
+	var i int64
+	for i = 0; i < length; i++ {
+		value, err := l.Index(i)
+		if err != nil {
+			fmt.Println("Error when reading Document Name", err.Error())
+			return err.Error(), index, false
+		}
+		name := string(value)
+		if len(strings.TrimSpace(name)) > 0 && !slices.Contains(index.Names, name) && db.Collection(name).Exists() {
+			index.Names = append(index.Names, name)
+		}
+	}
+	errMsg := putIndex(index)
+	if errMsg != "" {
+		return errMsg, index, false
+	}
+	return "", index, true
+}
+
+// putIndex stores the index and returns an error message if it fails. The
+// caller holds indexMutex.
+func putIndex(index DocumentIndex) string {
+	c := db.Collection(indexCollection)
+	err := c.Add(indexKey, index)
+	if err != nil {
+		fmt.Println("Error when writing Document Index", err.Error())
+		return err.Error()
+	}
+	return ""
+}
+
+func addToIndex(documentName string) (string, bool) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	if !ok {
+		return errMsg, false
+	}
+	if !slices.Contains(index.Names, documentName) {
+		index.Names = append(index.Names, documentName)
+	}
+	errMsg = putIndex(index)
+	return errMsg, errMsg == ""
+}
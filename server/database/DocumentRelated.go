@@ -1,36 +1,32 @@
 package database
 
-import (
-	"fmt"
+// GetAllDocumentNames returns the names of the documents that are not in the
+// trash.
+func GetAllDocumentNames() ([]string, bool) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	_, index, ok := getIndex()
+	return index.Names, ok
+}
 
-	"go.mills.io/bitcask/v2"
-)
+// DocumentExists reports whether a document of that name exists, in the
+// trash or not.
+func DocumentExists(documentName string) bool {
+	return db.Collection(documentName).Exists()
+}
 
-func GetAllDocumentNames() ([]string, bool) {
-	var documentNames = make([]string, 0)
-	l := db.List(bitcask.Key("documentNames"))
-	length, err := l.Len()
-	if err != nil {
-		fmt.Println("Error when reading Document Names", err.Error())
-		return documentNames, false
-	}
-	var i int64
-	for i = 0; i < length; i++ {
-		value, err := l.Index(i)
-		if err != nil {
-			fmt.Println("Error when reading Document Name", err.Error())
-			return documentNames, false
-		}
-		documentNames = append(documentNames, string(value))
+func GetDocumentDetails(documentName string, user User) (string, DocumentDetails, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, DocumentDetails{}, false
 	}
-	return documentNames, true
+	return getDocumentDetails(documentName)
 }
 
-func GetDocumentDetails(documentName string) (string, DocumentDetails, bool) {
+func getDocumentDetails(documentName string) (string, DocumentDetails, bool) {
 	details := DocumentDetails{}
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", details, false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, details, false
 	}
 	err := c.Get("DocumentDetails", &details)
 
@@ -40,11 +36,18 @@ func GetDocumentDetails(documentName string) (string, DocumentDetails, bool) {
 	return "", details, true
 }
 
-func GetSubsystemDetails(documentName string) (string, SubsystemDetails, bool) {
+func GetSubsystemDetails(documentName string, user User) (string, SubsystemDetails, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, SubsystemDetails{}, false
+	}
+	return getSubsystemDetails(documentName)
+}
+
+func getSubsystemDetails(documentName string) (string, SubsystemDetails, bool) {
 	details := SubsystemDetails{}
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", details, false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, details, false
 	}
 	err := c.Get("SubsystemDetails", &details)
 
@@ -54,16 +57,24 @@ func GetSubsystemDetails(documentName string) (string, SubsystemDetails, bool) {
 	return "", details, true
 }
 
-func GetContent(documentName string, subsection string) (string, Content, bool) {
+func GetContent(documentName string, subsection string, user User) (string, Content, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, Content{}, false
+	}
+	return getContent(documentName, subsection)
+}
+
+func getContent(documentName string, subsection string) (string, Content, bool) {
 	details := Content{}
 	c := db.Collection(documentName)
-	if !c.Exists() {
-		return "Document Doesn't Exist", details, false
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, details, false
 	}
 	err := c.Get(subsection, &details)
 
 	if err != nil {
 		return err.Error(), details, false
 	}
+	details.ItemIDs = getItemIDs(details)
 	return "", details, true
 }
@@ -1,5 +1,7 @@
 package database
 
+import "time"
+
 type DocumentDetails struct {
 	DocumentNumber      string
 	PreparedBy          string
@@ -11,6 +13,8 @@ type DocumentDetails struct {
 	SecondApproverTitle string
 	EID                 bool
 	ResultFormat        bool
+	Language            string
+	MnemonicCheck       bool
 }
 
 type SubsystemDetails struct {
@@ -27,4 +31,319 @@ type Content struct {
 	Value       []string
 	Captions    []string
 	Landscape   []bool
+	// ItemIDs identify the items across edits, see assignItemIDs.
+	ItemIDs []string
+}
+
+type DistributionEntry struct {
+	CopyNo   int
+	IssuedTo string
+	Remarks  string
+	Medium   string
+}
+
+type DistributionList struct {
+	Entries []DistributionEntry
+}
+
+type SectionLayout struct {
+	Orientation string
+	Columns     int
+	NewPage     bool
+}
+
+type DocumentLayout struct {
+	PaperSize        string
+	MarginTop        float64
+	MarginBottom     float64
+	MarginSide       float64
+	FontFamily       string
+	DevanagariFont   string
+	FontSize         float64
+	LineSpacing      float64
+	ParagraphSpacing float64
+	HeadingNumbering string
+	Sections         map[string]SectionLayout
+}
+
+type Telecommand struct {
+	Mnemonic       string
+	Code           string
+	Description    string
+	Type           string
+	VerificationTM string
+	Criticality    string
+}
+
+type TelecommandList struct {
+	Telecommands []Telecommand
+}
+
+type StatusWord struct {
+	Value  string
+	Status string
+}
+
+type TelemetryParameter struct {
+	Mnemonic    string
+	Channel     string
+	Units       string
+	Calibration string
+	LowerLimit  string
+	UpperLimit  string
+	StatusWords []StatusWord
+}
+
+type TelemetryList struct {
+	Parameters []TelemetryParameter
+}
+
+type ProcedureStep struct {
+	Action        string
+	Telecommand   string
+	Telemetry     string
+	ExpectedValue string
+	Tolerance     string
+	WaitTime      string
+	Remarks       string
+}
+
+type Procedure struct {
+	Name           string
+	Title          string
+	Phases         []string
+	Configurations []string
+	Requirements   []string
+	ResultFormat   string
+	Steps          []ProcedureStep
+}
+
+type TestMatrixOverride struct {
+	Procedure  string
+	Phase      string
+	Applicable bool
+}
+
+type TestMatrixSettings struct {
+	Automatic bool
+	Phases    []string
+	Overrides []TestMatrixOverride
+}
+
+type TestMatrixRow struct {
+	Procedure      string
+	Title          string
+	Configurations []string
+	Applicable     []bool
+}
+
+type TestMatrix struct {
+	Phases     []string
+	Rows       []TestMatrixRow
+	Unreadable []string
+}
+
+type Requirement struct {
+	ID   string
+	Text string
+}
+
+type RequirementList struct {
+	Requirements []Requirement
+}
+
+type TraceabilityRow struct {
+	Requirement   string
+	Section       string
+	Text          string
+	Procedures    []string
+	ResultFormats []string
+	Covered       bool
+}
+
+type UnknownRequirement struct {
+	Requirement string
+	Procedure   string
+}
+
+type Traceability struct {
+	Rows       []TraceabilityRow
+	Unknown    []UnknownRequirement
+	Unreadable []string
+}
+
+type StepResult struct {
+	Step       int
+	Observed   string
+	Status     string
+	AnomalyRef string
+	Remarks    string
+}
+
+type OutOfLimitSample struct {
+	Time       string
+	Step       int
+	Mnemonic   string
+	Value      string
+	LowerLimit string
+	UpperLimit string
+}
+
+type ProcedureResult struct {
+	Procedure   string
+	TestDate    string
+	Operator    string
+	Steps       []StepResult
+	OutOfLimits []OutOfLimitSample
+}
+
+type TestResults struct {
+	Results []ProcedureResult
+}
+
+type DocumentAccess struct {
+	Owner      string
+	Editors    []string
+	Viewers    []string
+	Restricted bool
+}
+
+type CommentReply struct {
+	Author string
+	Time   string
+	Text   string
+}
+
+// Comment is a review comment on an item of a subsection. Start and End
+// select a range of the item text, both are zero for the whole item.
+type Comment struct {
+	ID           int
+	Subsection   string
+	Item         int
+	ItemID       string
+	Start        int
+	End          int
+	Quote        string
+	Author       string
+	Time         string
+	Text         string
+	Replies      []CommentReply
+	Resolved     bool
+	ResolvedBy   string
+	ResolvedTime string
+}
+
+type CommentList struct {
+	NextID   int
+	Comments []Comment
+}
+
+type WorkflowTransition struct {
+	Action   string
+	From     string
+	To       string
+	Username string
+	Time     string
+	Comment  string
+}
+
+type Workflow struct {
+	State              string
+	ReviewedBy         string
+	ReviewedTime       string
+	FirstApprovedBy    string
+	FirstApprovedTime  string
+	SecondApprovedBy   string
+	SecondApprovedTime string
+	History            []WorkflowTransition
+}
+
+// Suggestion is a proposed replacement of a subsection, or of one item of it
+// if Item is not negative. Base is the content it was made against.
+type Suggestion struct {
+	ID          int
+	Subsection  string
+	Item        int
+	Content     Content
+	Base        Content
+	Author      string
+	Time        string
+	Comment     string
+	Status      string
+	DecidedBy   string
+	DecidedTime string
+}
+
+type SuggestionList struct {
+	NextID      int
+	Suggestions []Suggestion
+}
+
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+type ItemDiff struct {
+	Item  int
+	Lines []DiffLine
+}
+
+type SuggestionDiff struct {
+	Items    []ItemDiff
+	Outdated bool
+}
+
+type Revision struct {
+	Subsection  string
+	Item        int
+	Suggestion  int
+	SuggestedBy string
+	AcceptedBy  string
+	Time        string
+	Comment     string
+}
+
+type RevisionHistory struct {
+	Revisions []Revision
+}
+
+type SectionLock struct {
+	Subsection string
+	Owner      string
+	Acquired   time.Time
+	Expires    time.Time
+}
+
+type AuditEntry struct {
+	Time         string
+	Actor        string
+	ClientID     string
+	Action       string
+	DocumentName string
+	Target       string
+	Summary      string
+	OK           bool
+}
+
+type AuditFilter struct {
+	Actor        string
+	Action       string
+	DocumentName string
+	From         string
+	To           string
+	Offset       int
+	Limit        int
+}
+
+type TrashEntry struct {
+	DocumentName string
+	DeletedBy    string
+	Deleted      time.Time
+	Expires      time.Time
+}
+
+type DocumentIndex struct {
+	Names []string
+	Trash []TrashEntry
 }
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+)
+
+// legacyItemID is the ID of the item at position i of a content saved
+// before items had IDs.
+func legacyItemID(i int) string {
+	return strconv.Itoa(i + 1)
+}
+
+// getItemIDs returns the IDs of the items of the content, using the legacy
+// ID for items that have none.
+func getItemIDs(content Content) []string {
+	ids := make([]string, max(content.NoOfItems, 0))
+	for i := range ids {
+		if i < len(content.ItemIDs) && content.ItemIDs[i] != "" {
+			ids[i] = content.ItemIDs[i]
+		} else {
+			ids[i] = legacyItemID(i)
+		}
+	}
+	return ids
+}
+
+func newItemID() string {
+	data := make([]byte, 8)
+	rand.Read(data)
+	return hex.EncodeToString(data)
+}
+
+func sameItem(a Content, i int, b Content, j int) bool {
+	return a.ContentType[i] == b.ContentType[j] && a.FileName[i] == b.FileName[j] && a.Value[i] == b.Value[j] && a.Captions[i] == b.Captions[j]
+}
+
+// assignItemIDs gives every item of content an ID, so that comments stay on
+// their item when items are added, removed or moved. An item keeps the ID
+// it was sent with if that ID belongs to an item of the previous content,
+// otherwise an unchanged item keeps the ID of the previous item it equals.
+// Other items get a new ID.
+func assignItemIDs(previous Content, content Content) Content {
+	previousIDs := getItemIDs(previous)
+	_, comparable := validateContent(previous)
+	if _, ok := validateContent(content); !ok {
+		comparable = false
+	}
+	unused := make(map[string]bool)
+	for _, id := range previousIDs {
+		unused[id] = true
+	}
+	ids := make([]string, max(content.NoOfItems, 0))
+	for i := range ids {
+		if i < len(content.ItemIDs) && unused[content.ItemIDs[i]] {
+			ids[i] = content.ItemIDs[i]
+			unused[ids[i]] = false
+		}
+	}
+	for i := range ids {
+		if ids[i] != "" {
+			continue
+		}
+		for j, id := range previousIDs {
+			if comparable && unused[id] && sameItem(previous, j, content, i) {
+				ids[i] = id
+				unused[id] = false
+				break
+			}
+		}
+	}
+	for i := range ids {
+		if ids[i] == "" {
+			ids[i] = newItemID()
+		}
+	}
+	content.ItemIDs = ids
+	return content
+}
+
+// locateComments sets the Item of every comment to the current position of
+// the item it was made on, or to -1 if that item has been removed. Comments
+// made before items had IDs are on the item that was at their position.
+func locateComments(documentName string, comments CommentList) CommentList {
+	positions := make(map[string]map[string]int)
+	for i, comment := range comments.Comments {
+		if comment.ItemID == "" {
+			comment.ItemID = legacyItemID(comment.Item)
+		}
+		itemPositions, found := positions[comment.Subsection]
+		if !found {
+			itemPositions = make(map[string]int)
+			_, content, _ := getContent(documentName, comment.Subsection)
+			for j, id := range content.ItemIDs {
+				itemPositions[id] = j
+			}
+			positions[comment.Subsection] = itemPositions
+		}
+		position, found := itemPositions[comment.ItemID]
+		if !found {
+			position = -1
+		}
+		comment.Item = position
+		comments.Comments[i] = comment
+	}
+	return comments
+}
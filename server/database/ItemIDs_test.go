@@ -0,0 +1,55 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func makeTextContent(ids []string, values ...string) Content {
+	content := Content{NoOfItems: len(values), ItemIDs: ids}
+	for _, value := range values {
+		content.ContentType = append(content.ContentType, "Text")
+		content.FileName = append(content.FileName, "")
+		content.Value = append(content.Value, value)
+		content.Captions = append(content.Captions, "")
+		content.Landscape = append(content.Landscape, false)
+	}
+	return content
+}
+
+func TestAssignItemIDs(t *testing.T) {
+	previous := makeTextContent([]string{"a", "b", "c"}, "one", "two", "three")
+	tests := []struct {
+		name     string
+		previous Content
+		content  Content
+		want     []string
+	}{
+		{"unchanged", previous, makeTextContent(nil, "one", "two", "three"), []string{"a", "b", "c"}},
+		{"moved", previous, makeTextContent(nil, "three", "one", "two"), []string{"c", "a", "b"}},
+		{"removed", previous, makeTextContent(nil, "one", "three"), []string{"a", "c"}},
+		{"edited with its ID", previous, makeTextContent([]string{"a", "b", "c"}, "one", "2", "three"), []string{"a", "b", "c"}},
+		{"duplicate values", makeTextContent([]string{"a", "b"}, "same", "same"), makeTextContent(nil, "same", "same"), []string{"a", "b"}},
+		{"unknown ID", previous, makeTextContent([]string{"x", "", ""}, "one", "two", "three"), []string{"a", "b", "c"}},
+		{"legacy content", makeTextContent(nil, "one", "two"), makeTextContent(nil, "two", "one"), []string{"2", "1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := assignItemIDs(test.previous, test.content).ItemIDs
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("assignItemIDs() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAssignItemIDsNewItems(t *testing.T) {
+	previous := makeTextContent([]string{"a"}, "one")
+	got := assignItemIDs(previous, makeTextContent(nil, "zero", "one", "edited")).ItemIDs
+	if got[1] != "a" {
+		t.Errorf("unchanged item has ID %q, want %q", got[1], "a")
+	}
+	if got[0] == "" || got[2] == "" || got[0] == got[2] || got[0] == "a" || got[2] == "a" {
+		t.Errorf("new items have IDs %q and %q, want new distinct IDs", got[0], got[2])
+	}
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Subsections which traditionally start on a new page.
+var newPageSections = []string{
+	"Introduction-Telecommand",
+	"Introduction-Telemetry",
+	"Introduction-Pages",
+	"Checkout-SpecificRequirements",
+	"TestProcedures",
+}
+
+func getDefaultLayout() DocumentLayout {
+	var layout DocumentLayout
+	layout.PaperSize = "A4"
+	layout.MarginTop = 4
+	layout.MarginBottom = 2
+	layout.MarginSide = 1.5
+	layout.FontFamily = "Roboto"
+	layout.DevanagariFont = "Noto Sans Devanagari"
+	layout.FontSize = 11
+	layout.LineSpacing = 1.15
+	layout.ParagraphSpacing = 1.5
+	layout.HeadingNumbering = "1.1"
+	layout.Sections = make(map[string]SectionLayout)
+	return layout
+}
+
+// GetDefaultSectionLayout returns the layout used for a subsection that has
+// no override in the document layout.
+func GetDefaultSectionLayout(subsection string) SectionLayout {
+	var section SectionLayout
+	section.Orientation = "portrait"
+	section.Columns = 1
+	for _, name := range newPageSections {
+		if name == subsection {
+			section.NewPage = true
+		}
+	}
+	return section
+}
+
+// GetSectionLayout returns the override stored for the subsection, or the
+// default layout of the subsection if there is none.
+func GetSectionLayout(layout DocumentLayout, subsection string) SectionLayout {
+	section, ok := layout.Sections[subsection]
+	if !ok {
+		return GetDefaultSectionLayout(subsection)
+	}
+	return section
+}
+
+func GetLayout(documentName string, user User) (string, DocumentLayout, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultLayout(), false
+	}
+	return getLayout(documentName)
+}
+
+func getLayout(documentName string) (string, DocumentLayout, bool) {
+	layout := getDefaultLayout()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, layout, false
+	}
+	if !c.Has("Layout") {
+		return "", layout, true
+	}
+	err := c.Get("Layout", &layout)
+	if err != nil {
+		return err.Error(), layout, false
+	}
+	if layout.Sections == nil {
+		layout.Sections = make(map[string]SectionLayout)
+	}
+	return "", layout, true
+}
+
+// GetLayoutFonts returns the fonts used by the default layout and by the
+// layout of every document, each with the names of the documents using it.
+func GetLayoutFonts() map[string][]string {
+	fonts := make(map[string][]string)
+	addFonts := func(layout DocumentLayout, user string) {
+		devanagariFont := layout.DevanagariFont
+		if len(strings.TrimSpace(devanagariFont)) == 0 {
+			devanagariFont = getDefaultLayout().DevanagariFont
+		}
+		for _, font := range []string{layout.FontFamily, devanagariFont} {
+			font = strings.TrimSpace(font)
+			if font != "" && !slices.Contains(fonts[font], user) {
+				fonts[font] = append(fonts[font], user)
+			}
+		}
+	}
+	addFonts(getDefaultLayout(), "default layout")
+	documentNames, _ := GetAllDocumentNames()
+	for _, documentName := range documentNames {
+		_, layout, ok := getLayout(documentName)
+		if ok {
+			addFonts(layout, documentName)
+		}
+	}
+	return fonts
+}
+
+func AddLayout(documentName string, layout DocumentLayout, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	msg, ok := validateLayout(layout)
+	if !ok {
+		return msg, false
+	}
+	err := c.Add("Layout", layout)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+func validateLayout(layout DocumentLayout) (string, bool) {
+	paper := strings.ToLower(layout.PaperSize)
+	if paper != "a4" && paper != "letter" && paper != "a3" {
+		return "Paper Size must be A4, Letter or A3", false
+	}
+	if layout.MarginTop <= 0 || layout.MarginBottom <= 0 || layout.MarginSide <= 0 {
+		return "Margins must be greater than zero", false
+	}
+	if len(strings.TrimSpace(layout.FontFamily)) == 0 {
+		return "Font Family is empty", false
+	}
+	if layout.FontSize <= 0 {
+		return "Font Size must be greater than zero", false
+	}
+	if layout.LineSpacing <= 0 || layout.ParagraphSpacing <= 0 {
+		return "Spacing must be greater than zero", false
+	}
+	for name, section := range layout.Sections {
+		orientation := strings.ToLower(section.Orientation)
+		if orientation != "portrait" && orientation != "landscape" {
+			return "Orientation of " + name + " must be Portrait or Landscape", false
+		}
+		if section.Columns < 1 || section.Columns > 3 {
+			return "Columns of " + name + " must be between 1 and 3", false
+		}
+	}
+	return "", true
+}
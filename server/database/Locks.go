@@ -0,0 +1,170 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// getLocks returns the unexpired section locks of the document by
+// subsection.
+func getLocks(documentName string) (string, map[string]SectionLock, bool) {
+	locks := make(map[string]SectionLock)
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, locks, false
+	}
+	if !c.Has("Locks") {
+		return "", locks, true
+	}
+	err := c.Get("Locks", &locks)
+	if err != nil {
+		return err.Error(), locks, false
+	}
+	now := time.Now()
+	for subsection, lock := range locks {
+		if now.After(lock.Expires) {
+			delete(locks, subsection)
+		}
+	}
+	return "", locks, true
+}
+
+func saveLocks(documentName string, locks map[string]SectionLock) (string, bool) {
+	c := db.Collection(documentName)
+	err := c.Add("Locks", locks)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// GetLock returns the lock of the subsection, and false if it is not locked.
+func GetLock(documentName string, subsection string, user User) (SectionLock, bool) {
+	if _, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return SectionLock{}, false
+	}
+	return getLock(documentName, subsection)
+}
+
+func getLock(documentName string, subsection string) (SectionLock, bool) {
+	_, locks, _ := getLocks(documentName)
+	lock, ok := locks[subsection]
+	return lock, ok
+}
+
+func GetLocks(documentName string, user User) (string, []SectionLock, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, nil, false
+	}
+	return listLocks(documentName)
+}
+
+func listLocks(documentName string) (string, []SectionLock, bool) {
+	list := make([]SectionLock, 0)
+	errMsg, locks, ok := getLocks(documentName)
+	if !ok {
+		return errMsg, list, false
+	}
+	for _, lock := range locks {
+		list = append(list, lock)
+	}
+	return "", list, true
+}
+
+func lockedMessage(lock SectionLock) string {
+	return lock.Subsection + " is being edited by " + lock.Owner + " until " + lock.Expires.Format(timeFormat)
+}
+
+// checkLock refuses a write to a subsection locked by another user. The
+// writes call it under writeMutex, so that a lock cannot be taken between
+// the check and the write.
+func checkLock(documentName string, subsection string, username string) (string, bool) {
+	lock, locked := getLock(documentName, subsection)
+	if locked && lock.Owner != username {
+		return lockedMessage(lock), false
+	}
+	return "", true
+}
+
+// LockSection checks out the subsection for the user for the given
+// duration. Locking a subsection the user already holds renews it, which is
+// also the heartbeat. With steal, a lock held by another user is taken over.
+func LockSection(documentName string, subsection string, user User, duration time.Duration, steal bool) (string, SectionLock, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, SectionLock{}, false
+	}
+	return lockSection(documentName, subsection, user.Username, duration, steal)
+}
+
+func lockSection(documentName string, subsection string, username string, duration time.Duration, steal bool) (string, SectionLock, bool) {
+	var lock SectionLock
+	errMsg, locks, ok := getLocks(documentName)
+	if !ok {
+		return errMsg, lock, false
+	}
+	if !db.Collection(documentName).Has(subsection) {
+		return "Unknown Subsection " + subsection, lock, false
+	}
+	now := time.Now()
+	existing, locked := locks[subsection]
+	if locked && existing.Owner != username && !steal {
+		return lockedMessage(existing), existing, false
+	}
+	lock.Subsection = subsection
+	lock.Owner = username
+	lock.Acquired = now
+	if locked && existing.Owner == username {
+		lock.Acquired = existing.Acquired
+	}
+	lock.Expires = now.Add(duration)
+	locks[subsection] = lock
+	errMsg, ok = saveLocks(documentName, locks)
+	return errMsg, lock, ok
+}
+
+// HeartbeatLock extends a lock the user holds. It fails if the lock has
+// expired or has been taken over.
+func HeartbeatLock(documentName string, subsection string, user User, duration time.Duration) (string, SectionLock, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, SectionLock{}, false
+	}
+	lock, locked := getLock(documentName, subsection)
+	if !locked {
+		return subsection + " is not locked", lock, false
+	}
+	if lock.Owner != user.Username {
+		return lockedMessage(lock), lock, false
+	}
+	return lockSection(documentName, subsection, user.Username, duration, false)
+}
+
+// UnlockSection releases the lock of the subsection. Only the owner may,
+// unless force is set.
+func UnlockSection(documentName string, subsection string, user User, force bool) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, locks, ok := getLocks(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	lock, locked := locks[subsection]
+	if !locked {
+		return subsection + " is not locked", false
+	}
+	if lock.Owner != user.Username && !force {
+		return lockedMessage(lock), false
+	}
+	delete(locks, subsection)
+	return saveLocks(documentName, locks)
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Structured procedures are stored as items of content type "procedure" in
+// TestProcedures. The item value is the JSON of the Procedure, its file name
+// is the procedure name and its caption the title, so that the procedure list
+// is built the same way as for the other items.
+
+// ParseProcedure reads a procedure item of TestProcedures.
+func ParseProcedure(value string) (Procedure, error) {
+	var procedure Procedure
+	err := json.Unmarshal([]byte(value), &procedure)
+	if err != nil {
+		return procedure, err
+	}
+	if procedure.Steps == nil {
+		procedure.Steps = make([]ProcedureStep, 0)
+	}
+	if procedure.Phases == nil {
+		procedure.Phases = make([]string, 0)
+	}
+	if procedure.Configurations == nil {
+		procedure.Configurations = make([]string, 0)
+	}
+	if procedure.Requirements == nil {
+		procedure.Requirements = make([]string, 0)
+	}
+	return procedure, nil
+}
+
+// GetProcedures returns the structured procedures of the document in the
+// order they appear in TestProcedures. It fails if one of them cannot be
+// read, see GetReadableProcedures for the lenient form.
+func GetProcedures(documentName string, user User) (string, []Procedure, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, nil, false
+	}
+	return getProcedures(documentName)
+}
+
+func getProcedures(documentName string) (string, []Procedure, bool) {
+	errMsg, procedures, unreadable, ok := getReadableProcedures(documentName)
+	if !ok {
+		return errMsg, procedures, false
+	}
+	if len(unreadable) > 0 {
+		return "Procedure " + unreadable[0] + " cannot be read", procedures, false
+	}
+	return "", procedures, true
+}
+
+// GetReadableProcedures returns the procedures that can be read, like
+// GetProcedures, and the names of those that cannot instead of failing.
+func GetReadableProcedures(documentName string, user User) (string, []Procedure, []string, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, nil, nil, false
+	}
+	return getReadableProcedures(documentName)
+}
+
+func getReadableProcedures(documentName string) (string, []Procedure, []string, bool) {
+	procedures := make([]Procedure, 0)
+	unreadable := make([]string, 0)
+	errMsg, content, ok := getContent(documentName, "TestProcedures")
+	if !ok {
+		return errMsg, procedures, unreadable, false
+	}
+	for i := 0; i < content.NoOfItems; i++ {
+		if !strings.EqualFold(content.ContentType[i], "procedure") {
+			continue
+		}
+		procedure, err := ParseProcedure(content.Value[i])
+		if err != nil {
+			fmt.Println("Procedure " + content.FileName[i] + " cannot be read: " + err.Error())
+			unreadable = append(unreadable, content.FileName[i])
+			continue
+		}
+		procedure.Name = content.FileName[i]
+		procedure.Title = content.Captions[i]
+		procedures = append(procedures, procedure)
+	}
+	return "", procedures, unreadable, true
+}
+
+// AddProcedure replaces the procedure with the same name in TestProcedures,
+// or appends it if there is none.
+func AddProcedure(documentName string, procedure Procedure, user User) (string, bool) {
+	errMsg, ok := validateProcedure(procedure)
+	if !ok {
+		return errMsg, false
+	}
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok = CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, false
+	}
+	version := GetVersion(documentName, "TestProcedures")
+	errMsg, content, ok := getContent(documentName, "TestProcedures")
+	if !ok {
+		return errMsg, false
+	}
+	data, err := json.Marshal(procedure)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+
+	found := false
+	for i := 0; i < content.NoOfItems; i++ {
+		if strings.EqualFold(content.ContentType[i], "procedure") && content.FileName[i] == procedure.Name {
+			content.Value[i] = string(data)
+			content.Captions[i] = procedure.Title
+			found = true
+			break
+		}
+	}
+	if !found {
+		content.ContentType = append(content.ContentType, "procedure")
+		content.FileName = append(content.FileName, procedure.Name)
+		content.Value = append(content.Value, string(data))
+		content.Captions = append(content.Captions, procedure.Title)
+		content.Landscape = append(content.Landscape, false)
+		content.NoOfItems = content.NoOfItems + 1
+	}
+	errMsg, _, ok = updateContent(documentName, "TestProcedures", content, version, user.Username)
+	return errMsg, ok
+}
+
+func validateProcedure(procedure Procedure) (string, bool) {
+	if len(strings.TrimSpace(procedure.Name)) == 0 {
+		return "Procedure Name is empty", false
+	}
+	if len(procedure.Steps) == 0 {
+		return "Procedure " + procedure.Name + " has no steps", false
+	}
+	for i, step := range procedure.Steps {
+		if len(strings.TrimSpace(step.Action)) == 0 {
+			return "Step " + strconv.Itoa(i+1) + " of " + procedure.Name + " has no Action", false
+		}
+	}
+	return "", true
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequirementSections are the subsections that can hold requirement lists.
+var RequirementSections = []string{"Introduction-SSSpecification", "Checkout-SpecificRequirements"}
+
+func getRequirementKey(subsection string) (string, bool) {
+	for _, section := range RequirementSections {
+		if section == subsection {
+			return subsection + "List", true
+		}
+	}
+	return "", false
+}
+
+// GetRequirements returns the requirement list of a subsection, which is
+// empty for documents that do not have one yet.
+func GetRequirements(documentName string, subsection string, user User) (string, RequirementList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, RequirementList{}, false
+	}
+	return getRequirements(documentName, subsection)
+}
+
+func getRequirements(documentName string, subsection string) (string, RequirementList, bool) {
+	list := RequirementList{}
+	list.Requirements = make([]Requirement, 0)
+	key, ok := getRequirementKey(subsection)
+	if !ok {
+		return subsection + " cannot hold Requirements", list, false
+	}
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, list, false
+	}
+	if !c.Has(key) {
+		return "", list, true
+	}
+	err := c.Get(key, &list)
+	if err != nil {
+		return err.Error(), list, false
+	}
+	if list.Requirements == nil {
+		list.Requirements = make([]Requirement, 0)
+	}
+	return "", list, true
+}
+
+// AddRequirements stores the requirement list of a subsection for the user,
+// unless another user holds the lock of the subsection. Requirement IDs must
+// be unique across all requirement lists of the document.
+func AddRequirements(documentName string, subsection string, list RequirementList, user User) (string, bool) {
+	key, ok := getRequirementKey(subsection)
+	if !ok {
+		return subsection + " cannot hold Requirements", false
+	}
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, ok = checkLock(documentName, subsection, user.Username)
+	if !ok {
+		return errMsg, false
+	}
+	ids := make(map[string]string)
+	for _, section := range RequirementSections {
+		if section == subsection {
+			continue
+		}
+		errMsg, other, ok := getRequirements(documentName, section)
+		if !ok {
+			return errMsg, false
+		}
+		for _, req := range other.Requirements {
+			ids[strings.ToUpper(req.ID)] = section
+		}
+	}
+	for _, req := range list.Requirements {
+		if len(strings.TrimSpace(req.ID)) == 0 {
+			return "Requirement ID is empty", false
+		}
+		if len(strings.TrimSpace(req.Text)) == 0 {
+			return "Requirement " + req.ID + " has no Text", false
+		}
+		section, found := ids[strings.ToUpper(req.ID)]
+		if found {
+			return "Requirement " + req.ID + " is already defined in " + section, false
+		}
+		ids[strings.ToUpper(req.ID)] = subsection
+	}
+	err := c.Add(key, list)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// GetTraceability links every requirement of the document to the procedures
+// that verify it and their result formats. Requirement IDs used by
+// procedures but not defined are returned as Unknown. Procedures that cannot
+// be read are left out and named in Unreadable.
+func GetTraceability(documentName string, user User) (string, Traceability, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, Traceability{}, false
+	}
+	return getTraceability(documentName)
+}
+
+func getTraceability(documentName string) (string, Traceability, bool) {
+	trace := Traceability{}
+	trace.Rows = make([]TraceabilityRow, 0)
+	trace.Unknown = make([]UnknownRequirement, 0)
+	errMsg, procedures, unreadable, ok := getReadableProcedures(documentName)
+	if !ok {
+		return errMsg, trace, false
+	}
+	trace.Unreadable = unreadable
+
+	rows := make(map[string]int)
+	for _, section := range RequirementSections {
+		errMsg, list, ok := getRequirements(documentName, section)
+		if !ok {
+			return errMsg, trace, false
+		}
+		for _, req := range list.Requirements {
+			row := TraceabilityRow{}
+			row.Requirement = req.ID
+			row.Section = section
+			row.Text = req.Text
+			row.Procedures = make([]string, 0)
+			row.ResultFormats = make([]string, 0)
+			rows[strings.ToUpper(req.ID)] = len(trace.Rows)
+			trace.Rows = append(trace.Rows, row)
+		}
+	}
+
+	for _, procedure := range procedures {
+		for _, id := range procedure.Requirements {
+			index, found := rows[strings.ToUpper(strings.TrimSpace(id))]
+			if !found {
+				trace.Unknown = append(trace.Unknown, UnknownRequirement{Requirement: id, Procedure: procedure.Name})
+				continue
+			}
+			row := &trace.Rows[index]
+			row.Procedures = append(row.Procedures, procedure.Name)
+			row.Covered = true
+			if procedure.ResultFormat != "" {
+				row.ResultFormats = append(row.ResultFormats, procedure.ResultFormat)
+			}
+		}
+	}
+	return "", trace, true
+}
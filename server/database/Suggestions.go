@@ -0,0 +1,277 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Status of a suggestion.
+const (
+	SuggestionOpen     = "Open"
+	SuggestionAccepted = "Accepted"
+	SuggestionRejected = "Rejected"
+)
+
+func getDefaultSuggestions() SuggestionList {
+	var suggestions SuggestionList
+	suggestions.NextID = 1
+	suggestions.Suggestions = make([]Suggestion, 0)
+	return suggestions
+}
+
+func GetSuggestions(documentName string, user User) (string, SuggestionList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultSuggestions(), false
+	}
+	return getSuggestions(documentName)
+}
+
+func getSuggestions(documentName string) (string, SuggestionList, bool) {
+	suggestions := getDefaultSuggestions()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, suggestions, false
+	}
+	if !c.Has("Suggestions") {
+		return "", suggestions, true
+	}
+	err := c.Get("Suggestions", &suggestions)
+	if err != nil {
+		return err.Error(), suggestions, false
+	}
+	return "", suggestions, true
+}
+
+func GetRevisions(documentName string, user User) (string, RevisionHistory, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, RevisionHistory{}, false
+	}
+	return getRevisions(documentName)
+}
+
+func getRevisions(documentName string) (string, RevisionHistory, bool) {
+	var history RevisionHistory
+	history.Revisions = make([]Revision, 0)
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, history, false
+	}
+	if !c.Has("Revisions") {
+		return "", history, true
+	}
+	err := c.Get("Revisions", &history)
+	if err != nil {
+		return err.Error(), history, false
+	}
+	return "", history, true
+}
+
+func validateContent(content Content) (string, bool) {
+	n := content.NoOfItems
+	if n < 0 || len(content.ContentType) != n || len(content.FileName) != n || len(content.Value) != n || len(content.Captions) != n || len(content.Landscape) != n {
+		return "Content does not have NoOfItems entries in every list", false
+	}
+	return "", true
+}
+
+// getItem returns item i of the content as a content of one item.
+func getItem(content Content, i int) Content {
+	var item Content
+	item.NoOfItems = 1
+	item.ContentType = []string{content.ContentType[i]}
+	item.FileName = []string{content.FileName[i]}
+	item.Value = []string{content.Value[i]}
+	item.Captions = []string{content.Captions[i]}
+	item.Landscape = []bool{content.Landscape[i]}
+	return item
+}
+
+// getSuggestionBase returns what the suggestion would replace in the current
+// content of its subsection.
+func getSuggestionBase(documentName string, suggestion Suggestion) (string, Content, bool) {
+	errMsg, current, ok := getContent(documentName, suggestion.Subsection)
+	if !ok {
+		return errMsg, current, false
+	}
+	if suggestion.Item < 0 {
+		// The base is compared by value, the item IDs are not part of it
+		current.ItemIDs = nil
+		return "", current, true
+	}
+	if suggestion.Item >= current.NoOfItems {
+		return fmt.Sprintf("Item %d does not exist in %s", suggestion.Item+1, suggestion.Subsection), current, false
+	}
+	return "", getItem(current, suggestion.Item), true
+}
+
+// AddSuggestion stores a replacement proposed by the user for a subsection,
+// or for one item of it, and returns it with its ID.
+func AddSuggestion(documentName string, suggestion Suggestion, user User) (string, Suggestion, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessView)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	errMsg, suggestions, ok := getSuggestions(documentName)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	errMsg, ok = validateContent(suggestion.Content)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	if suggestion.Item >= 0 && suggestion.Content.NoOfItems != 1 {
+		return "A suggestion for one item must have exactly one item", suggestion, false
+	}
+	if suggestion.Item < -1 {
+		suggestion.Item = -1
+	}
+	errMsg, base, ok := getSuggestionBase(documentName, suggestion)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	suggestion.ID = suggestions.NextID
+	suggestion.Author = user.Username
+	suggestion.Base = base
+	suggestion.Time = time.Now().Format(timeFormat)
+	suggestion.Status = SuggestionOpen
+	suggestion.DecidedBy = ""
+	suggestion.DecidedTime = ""
+	suggestions.NextID = suggestions.NextID + 1
+	suggestions.Suggestions = append(suggestions.Suggestions, suggestion)
+	c := db.Collection(documentName)
+	err := c.Add("Suggestions", suggestions)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), suggestion, false
+	}
+	return "", suggestion, true
+}
+
+func findSuggestion(suggestions SuggestionList, id int) int {
+	for i, suggestion := range suggestions.Suggestions {
+		if suggestion.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetSuggestionDiff compares the suggestion with the current content of its
+// subsection. Outdated is set if the content changed since the suggestion
+// was made.
+func GetSuggestionDiff(documentName string, id int, user User) (string, SuggestionDiff, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, SuggestionDiff{}, false
+	}
+	return getSuggestionDiff(documentName, id)
+}
+
+func getSuggestionDiff(documentName string, id int) (string, SuggestionDiff, bool) {
+	var diff SuggestionDiff
+	diff.Items = make([]ItemDiff, 0)
+	errMsg, suggestions, ok := getSuggestions(documentName)
+	if !ok {
+		return errMsg, diff, false
+	}
+	i := findSuggestion(suggestions, id)
+	if i < 0 {
+		return "Suggestion Doesn't Exist", diff, false
+	}
+	suggestion := suggestions.Suggestions[i]
+	errMsg, base, ok := getSuggestionBase(documentName, suggestion)
+	if !ok {
+		diff.Outdated = true
+		return errMsg, diff, false
+	}
+	offset := max(suggestion.Item, 0)
+	diff.Items = diffContent(base, suggestion.Content, offset)
+	diff.Outdated = !reflect.DeepEqual(base, suggestion.Base)
+	return "", diff, true
+}
+
+// DecideSuggestion accepts or rejects an open suggestion. Accepting writes
+// the suggested content, which needs the document to be a draft whose
+// subsection has not changed since the suggestion was made and is not locked
+// by someone else, and records a revision. The decided suggestion is
+// returned. It runs under writeMutex, like AddSuggestion, so that concurrent
+// decisions cannot lose a revision or reopen a decided suggestion.
+func DecideSuggestion(documentName string, id int, user User, accept bool, comment string) (string, Suggestion, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	var suggestion Suggestion
+	errMsg, ok := CheckAccess(documentName, user, AccessEdit)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	errMsg, suggestions, ok := getSuggestions(documentName)
+	if !ok {
+		return errMsg, suggestion, false
+	}
+	i := findSuggestion(suggestions, id)
+	if i < 0 {
+		return "Suggestion Doesn't Exist", suggestion, false
+	}
+	suggestion = suggestions.Suggestions[i]
+	if suggestion.Status != SuggestionOpen {
+		return "Suggestion is already " + suggestion.Status, suggestion, false
+	}
+	now := time.Now().Format(timeFormat)
+	if accept {
+		version := GetVersion(documentName, suggestion.Subsection)
+		errMsg, current, ok := getContent(documentName, suggestion.Subsection)
+		if !ok {
+			return errMsg, suggestion, false
+		}
+		errMsg, base, ok := getSuggestionBase(documentName, suggestion)
+		if !ok || !reflect.DeepEqual(base, suggestion.Base) {
+			return suggestion.Subsection + " has changed since the suggestion was made", suggestion, false
+		}
+		updated := suggestion.Content
+		if suggestion.Item >= 0 {
+			updated = current
+			updated.ContentType[suggestion.Item] = suggestion.Content.ContentType[0]
+			updated.FileName[suggestion.Item] = suggestion.Content.FileName[0]
+			updated.Value[suggestion.Item] = suggestion.Content.Value[0]
+			updated.Captions[suggestion.Item] = suggestion.Content.Captions[0]
+			updated.Landscape[suggestion.Item] = suggestion.Content.Landscape[0]
+		}
+		errMsg, _, ok = updateContent(documentName, suggestion.Subsection, updated, version, user.Username)
+		if !ok {
+			return errMsg, suggestion, false
+		}
+		errMsg, history, ok := getRevisions(documentName)
+		if !ok {
+			return errMsg, suggestion, false
+		}
+		var revision Revision
+		revision.Subsection = suggestion.Subsection
+		revision.Item = suggestion.Item
+		revision.Suggestion = suggestion.ID
+		revision.SuggestedBy = suggestion.Author
+		revision.AcceptedBy = user.Username
+		revision.Time = now
+		revision.Comment = comment
+		history.Revisions = append(history.Revisions, revision)
+		c := db.Collection(documentName)
+		err := c.Add("Revisions", history)
+		if err != nil {
+			fmt.Println(err.Error())
+			return err.Error(), suggestion, false
+		}
+		suggestions.Suggestions[i].Status = SuggestionAccepted
+	} else {
+		suggestions.Suggestions[i].Status = SuggestionRejected
+	}
+	suggestions.Suggestions[i].DecidedBy = user.Username
+	suggestions.Suggestions[i].DecidedTime = now
+	c := db.Collection(documentName)
+	err := c.Add("Suggestions", suggestions)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), suggestion, false
+	}
+	return "", suggestions.Suggestions[i], true
+}
@@ -0,0 +1,60 @@
+package database
+
+import "fmt"
+
+// GetTelecommands returns the telecommand list of the document, which is
+// empty for documents that do not have one yet.
+func GetTelecommands(documentName string, user User) (string, TelecommandList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, TelecommandList{}, false
+	}
+	return getTelecommands(documentName)
+}
+
+func getTelecommands(documentName string) (string, TelecommandList, bool) {
+	list := TelecommandList{}
+	list.Telecommands = make([]Telecommand, 0)
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, list, false
+	}
+	if !c.Has("Introduction-TelecommandList") {
+		return "", list, true
+	}
+	err := c.Get("Introduction-TelecommandList", &list)
+	if err != nil {
+		return err.Error(), list, false
+	}
+	if list.Telecommands == nil {
+		list.Telecommands = make([]Telecommand, 0)
+	}
+	return "", list, true
+}
+
+// AddTelecommands stores the list for the user, unless another user holds the lock
+// of the Introduction-Telecommand subsection.
+func AddTelecommands(documentName string, list TelecommandList, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, ok = checkLock(documentName, "Introduction-Telecommand", user.Username)
+	if !ok {
+		return errMsg, false
+	}
+	err := c.Add("Introduction-TelecommandList", list)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
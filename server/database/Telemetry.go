@@ -0,0 +1,60 @@
+package database
+
+import "fmt"
+
+// GetTelemetry returns the telemetry parameter list of the document, which
+// is empty for documents that do not have one yet.
+func GetTelemetry(documentName string, user User) (string, TelemetryList, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, TelemetryList{}, false
+	}
+	return getTelemetry(documentName)
+}
+
+func getTelemetry(documentName string) (string, TelemetryList, bool) {
+	list := TelemetryList{}
+	list.Parameters = make([]TelemetryParameter, 0)
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, list, false
+	}
+	if !c.Has("Introduction-TelemetryList") {
+		return "", list, true
+	}
+	err := c.Get("Introduction-TelemetryList", &list)
+	if err != nil {
+		return err.Error(), list, false
+	}
+	if list.Parameters == nil {
+		list.Parameters = make([]TelemetryParameter, 0)
+	}
+	return "", list, true
+}
+
+// AddTelemetry stores the list for the user, unless another user holds the lock
+// of the Introduction-Telemetry subsection.
+func AddTelemetry(documentName string, list TelemetryList, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, ok = checkLock(documentName, "Introduction-Telemetry", user.Username)
+	if !ok {
+		return errMsg, false
+	}
+	err := c.Add("Introduction-TelemetryList", list)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
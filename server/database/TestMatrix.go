@@ -0,0 +1,160 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+func getDefaultTestMatrixSettings() TestMatrixSettings {
+	settings := TestMatrixSettings{}
+	settings.Automatic = true
+	settings.Phases = []string{"Bare Bus", "Integrated", "Thermal Vacuum", "EMI/EMC", "Pre-Launch"}
+	settings.Overrides = make([]TestMatrixOverride, 0)
+	return settings
+}
+
+// GetTestMatrixSettings returns the phases and manual overrides used to
+// compute the test matrix, or the defaults if the document has none.
+func GetTestMatrixSettings(documentName string, user User) (string, TestMatrixSettings, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultTestMatrixSettings(), false
+	}
+	return getTestMatrixSettings(documentName)
+}
+
+func getTestMatrixSettings(documentName string) (string, TestMatrixSettings, bool) {
+	settings := getDefaultTestMatrixSettings()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, settings, false
+	}
+	if !c.Has("TestMatrixSettings") {
+		return "", settings, true
+	}
+	err := c.Get("TestMatrixSettings", &settings)
+	if err != nil {
+		return err.Error(), settings, false
+	}
+	if settings.Phases == nil {
+		settings.Phases = make([]string, 0)
+	}
+	if settings.Overrides == nil {
+		settings.Overrides = make([]TestMatrixOverride, 0)
+	}
+	return "", settings, true
+}
+
+func AddTestMatrixSettings(documentName string, settings TestMatrixSettings, user User) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, false
+	}
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, ok := checkDraft(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	for _, phase := range settings.Phases {
+		if len(strings.TrimSpace(phase)) == 0 {
+			return "Phase name is empty", false
+		}
+	}
+	for _, override := range settings.Overrides {
+		if len(strings.TrimSpace(override.Procedure)) == 0 || len(strings.TrimSpace(override.Phase)) == 0 {
+			return "Override needs a Procedure and a Phase", false
+		}
+	}
+	err := c.Add("TestMatrixSettings", settings)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// GetTestMatrix computes the test matrix of the document from the phases its
+// structured procedures are tagged with. Procedures that cannot be read are
+// left out and named in Unreadable.
+func GetTestMatrix(documentName string, user User) (string, TestMatrix, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, TestMatrix{}, false
+	}
+	return getTestMatrix(documentName)
+}
+
+func getTestMatrix(documentName string) (string, TestMatrix, bool) {
+	errMsg, settings, ok := getTestMatrixSettings(documentName)
+	if !ok {
+		return errMsg, TestMatrix{}, false
+	}
+	errMsg, procedures, unreadable, ok := getReadableProcedures(documentName)
+	if !ok {
+		return errMsg, TestMatrix{}, false
+	}
+	matrix := ComputeTestMatrix(procedures, settings)
+	matrix.Unreadable = unreadable
+	return "", matrix, true
+}
+
+// ComputeTestMatrix marks each procedure as applicable to the phases it is
+// tagged with, then applies the manual overrides. Phases are compared without
+// case. Phases used by procedures but missing from the settings are added as
+// extra columns so that no tag is lost.
+func ComputeTestMatrix(procedures []Procedure, settings TestMatrixSettings) TestMatrix {
+	matrix := TestMatrix{}
+	matrix.Phases = make([]string, 0)
+	matrix.Rows = make([]TestMatrixRow, 0)
+
+	columns := make(map[string]int)
+	addPhase := func(phase string) {
+		key := strings.ToLower(strings.TrimSpace(phase))
+		if key == "" {
+			return
+		}
+		if _, ok := columns[key]; ok {
+			return
+		}
+		columns[key] = len(matrix.Phases)
+		matrix.Phases = append(matrix.Phases, strings.TrimSpace(phase))
+	}
+	for _, phase := range settings.Phases {
+		addPhase(phase)
+	}
+	for _, procedure := range procedures {
+		for _, phase := range procedure.Phases {
+			addPhase(phase)
+		}
+	}
+
+	for _, procedure := range procedures {
+		row := TestMatrixRow{}
+		row.Procedure = procedure.Name
+		row.Title = procedure.Title
+		row.Configurations = make([]string, 0)
+		row.Configurations = append(row.Configurations, procedure.Configurations...)
+		row.Applicable = make([]bool, len(matrix.Phases))
+		if settings.Automatic {
+			for _, phase := range procedure.Phases {
+				column, ok := columns[strings.ToLower(strings.TrimSpace(phase))]
+				if ok {
+					row.Applicable[column] = true
+				}
+			}
+		}
+		for _, override := range settings.Overrides {
+			if override.Procedure != procedure.Name {
+				continue
+			}
+			column, ok := columns[strings.ToLower(strings.TrimSpace(override.Phase))]
+			if ok {
+				row.Applicable[column] = override.Applicable
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeTestMatrix(t *testing.T) {
+	procedures := []Procedure{
+		{Name: "P-1", Title: "Power", Phases: []string{"Bare Bus", " thermal vacuum "}, Configurations: []string{"Nominal"}},
+		{Name: "P-2", Title: "Payload", Phases: []string{"Integrated", "Acoustic"}},
+		{Name: "P-3", Title: "Untagged"},
+	}
+	tests := []struct {
+		name     string
+		settings TestMatrixSettings
+		phases   []string
+		rows     [][]bool
+	}{
+		{
+			name:     "automatic",
+			settings: TestMatrixSettings{Automatic: true, Phases: []string{"Bare Bus", "Integrated", "Thermal Vacuum"}},
+			phases:   []string{"Bare Bus", "Integrated", "Thermal Vacuum", "Acoustic"},
+			rows:     [][]bool{{true, false, true, false}, {false, true, false, true}, {false, false, false, false}},
+		},
+		{
+			name: "overrides",
+			settings: TestMatrixSettings{Automatic: true, Phases: []string{"Bare Bus", "Integrated", "Thermal Vacuum"}, Overrides: []TestMatrixOverride{
+				{Procedure: "P-1", Phase: "bare bus", Applicable: false},
+				{Procedure: "P-3", Phase: "Integrated", Applicable: true},
+				{Procedure: "P-3", Phase: "Unknown", Applicable: true},
+			}},
+			phases: []string{"Bare Bus", "Integrated", "Thermal Vacuum", "Acoustic"},
+			rows:   [][]bool{{false, false, true, false}, {false, true, false, true}, {false, true, false, false}},
+		},
+		{
+			name: "manual",
+			settings: TestMatrixSettings{Automatic: false, Phases: []string{"Integrated", ""}, Overrides: []TestMatrixOverride{
+				{Procedure: "P-2", Phase: "Integrated", Applicable: true},
+			}},
+			phases: []string{"Integrated", "Bare Bus", "thermal vacuum", "Acoustic"},
+			rows:   [][]bool{{false, false, false, false}, {true, false, false, false}, {false, false, false, false}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matrix := ComputeTestMatrix(procedures, test.settings)
+			if !reflect.DeepEqual(matrix.Phases, test.phases) {
+				t.Errorf("Phases = %q, want %q", matrix.Phases, test.phases)
+			}
+			if len(matrix.Rows) != len(procedures) {
+				t.Fatalf("got %d rows, want %d", len(matrix.Rows), len(procedures))
+			}
+			for i, row := range matrix.Rows {
+				if row.Procedure != procedures[i].Name || row.Title != procedures[i].Title {
+					t.Errorf("row %d is %s %s, want %s %s", i, row.Procedure, row.Title, procedures[i].Name, procedures[i].Title)
+				}
+				if !reflect.DeepEqual(row.Applicable, test.rows[i]) {
+					t.Errorf("row %s Applicable = %v, want %v", row.Procedure, row.Applicable, test.rows[i])
+				}
+			}
+			if !reflect.DeepEqual(matrix.Rows[0].Configurations, []string{"Nominal"}) || matrix.Rows[1].Configurations == nil {
+				t.Errorf("Configurations = %q and %q", matrix.Rows[0].Configurations, matrix.Rows[1].Configurations)
+			}
+		})
+	}
+}
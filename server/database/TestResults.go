@@ -0,0 +1,102 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetTestResults returns the results recorded for the procedures of the
+// document, which is empty before the test campaign.
+func GetTestResults(documentName string, user User) (string, TestResults, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, TestResults{}, false
+	}
+	return getTestResults(documentName)
+}
+
+func getTestResults(documentName string) (string, TestResults, bool) {
+	results := TestResults{}
+	results.Results = make([]ProcedureResult, 0)
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, results, false
+	}
+	if !c.Has("TestResults") {
+		return "", results, true
+	}
+	err := c.Get("TestResults", &results)
+	if err != nil {
+		return err.Error(), results, false
+	}
+	if results.Results == nil {
+		results.Results = make([]ProcedureResult, 0)
+	}
+	return "", results, true
+}
+
+// AddProcedureResult replaces the results of a procedure. Steps are numbered
+// from 1 in the order of the procedure, and their status is Pass, Fail or
+// empty for steps that were not run.
+func AddProcedureResult(documentName string, result ProcedureResult, user User) (string, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessEdit); !ok {
+		return errMsg, false
+	}
+	errMsg, procedures, ok := getProcedures(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	var procedure *Procedure
+	for i := range procedures {
+		if procedures[i].Name == result.Procedure {
+			procedure = &procedures[i]
+			break
+		}
+	}
+	if procedure == nil {
+		return "Procedure " + result.Procedure + " doesn't exist", false
+	}
+	seen := make(map[int]bool)
+	for i, step := range result.Steps {
+		if step.Step < 1 || step.Step > len(procedure.Steps) {
+			return "Step " + strconv.Itoa(step.Step) + " is not a step of " + procedure.Name, false
+		}
+		if seen[step.Step] {
+			return "Step " + strconv.Itoa(step.Step) + " has more than one result", false
+		}
+		seen[step.Step] = true
+		switch strings.ToLower(strings.TrimSpace(step.Status)) {
+		case "pass":
+			result.Steps[i].Status = "Pass"
+		case "fail":
+			result.Steps[i].Status = "Fail"
+		case "":
+			result.Steps[i].Status = ""
+		default:
+			return "Status of Step " + strconv.Itoa(step.Step) + " must be Pass or Fail", false
+		}
+	}
+
+	errMsg, results, ok := getTestResults(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	found := false
+	for i := range results.Results {
+		if results.Results[i].Procedure == result.Procedure {
+			results.Results[i] = result
+			found = true
+			break
+		}
+	}
+	if !found {
+		results.Results = append(results.Results, result)
+	}
+	c := db.Collection(documentName)
+	err := c.Add("TestResults", results)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
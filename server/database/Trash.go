@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DeleteDocument moves the document to the trash, where it is kept for the
+// given time before it is purged. A document in the trash keeps all its
+// data but is not listed and cannot be opened until it is restored.
+func DeleteDocument(documentName string, deletedBy string, retention time.Duration) (string, bool) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	if !ok {
+		return errMsg, false
+	}
+	i := slices.Index(index.Names, documentName)
+	if i < 0 || !db.Collection(documentName).Exists() {
+		return "Document Doesn't Exist", false
+	}
+	now := time.Now()
+	index.Names = slices.Delete(index.Names, i, i+1)
+	index.Trash = append(index.Trash, TrashEntry{
+		DocumentName: documentName,
+		DeletedBy:    deletedBy,
+		Deleted:      now,
+		Expires:      now.Add(retention),
+	})
+	errMsg = putIndex(index)
+	return errMsg, errMsg == ""
+}
+
+// GetTrash returns the documents in the trash, oldest deletion first.
+func GetTrash() (string, []TrashEntry, bool) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	return errMsg, index.Trash, ok
+}
+
+func IsTrashed(documentName string) bool {
+	_, trash, _ := GetTrash()
+	return slices.ContainsFunc(trash, func(entry TrashEntry) bool {
+		return entry.DocumentName == documentName
+	})
+}
+
+// checkDocument returns why the document cannot be read or changed, because
+// it does not exist or is in the trash. Every function on a document checks
+// it, so that the trash holds whichever route leads there.
+func checkDocument(documentName string) (string, bool) {
+	if !DocumentExists(documentName) {
+		return "Document Doesn't Exist", false
+	}
+	if IsTrashed(documentName) {
+		return documentName + " is in the Trash", false
+	}
+	return "", true
+}
+
+func findTrashEntry(index DocumentIndex, documentName string) (string, int, bool) {
+	i := slices.IndexFunc(index.Trash, func(entry TrashEntry) bool {
+		return entry.DocumentName == documentName
+	})
+	if i < 0 {
+		return "Document is not in the Trash", i, false
+	}
+	return "", i, true
+}
+
+// RestoreDocument takes the document out of the trash.
+func RestoreDocument(documentName string) (string, bool) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	if !ok {
+		return errMsg, false
+	}
+	errMsg, i, ok := findTrashEntry(index, documentName)
+	if !ok {
+		return errMsg, false
+	}
+	index.Trash = slices.Delete(index.Trash, i, i+1)
+	index.Names = append(index.Names, documentName)
+	errMsg = putIndex(index)
+	return errMsg, errMsg == ""
+}
+
+// PurgeDocument removes a document in the trash for good.
+func PurgeDocument(documentName string) (string, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	if !ok {
+		return errMsg, false
+	}
+	return purgeDocument(index, documentName)
+}
+
+// purgeDocument takes the document off the index before dropping its data,
+// so that a failure in between leaves data nobody can reach rather than an
+// entry without data. The caller holds writeMutex, so that no write on the
+// document is half done when it is dropped, and indexMutex.
+func purgeDocument(index DocumentIndex, documentName string) (string, bool) {
+	errMsg, i, ok := findTrashEntry(index, documentName)
+	if !ok {
+		return errMsg, false
+	}
+	index.Trash = slices.Delete(index.Trash, i, i+1)
+	errMsg = putIndex(index)
+	if errMsg != "" {
+		return errMsg, false
+	}
+	c := db.Collection(documentName)
+	if c.Exists() {
+		err := c.Drop()
+		if err != nil {
+			fmt.Println("Error when purging", documentName, err.Error())
+			return "Document Can't be purged", false
+		}
+	}
+	db.Sync()
+	return "", true
+}
+
+// PurgeExpiredDocuments purges the documents whose time in the trash is up
+// and returns their names.
+func PurgeExpiredDocuments() (string, []string, bool) {
+	purged := make([]string, 0)
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+	errMsg, index, ok := getIndex()
+	if !ok {
+		return errMsg, purged, false
+	}
+	now := time.Now()
+	for _, entry := range index.Trash {
+		if now.After(entry.Expires) {
+			purged = append(purged, entry.DocumentName)
+		}
+	}
+	for i, documentName := range purged {
+		// purgeDocument writes the index, so it is read again for each one
+		errMsg, index, ok = getIndex()
+		if ok {
+			errMsg, ok = purgeDocument(index, documentName)
+		}
+		if !ok {
+			return errMsg, purged[:i], false
+		}
+	}
+	return "", purged, true
+}
@@ -0,0 +1,250 @@
+package database
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Users and sessions are kept in collections whose names start with an
+// underscore, which document names may not use.
+const usersCollection = "_users"
+const sessionsCollection = "_sessions"
+
+const (
+	RoleAuthor   = "author"
+	RoleReviewer = "reviewer"
+	RoleApprover = "approver"
+	RoleAdmin    = "admin"
+)
+
+type User struct {
+	Username     string
+	FullName     string
+	Role         string
+	PasswordHash string
+}
+
+type Session struct {
+	Token    string
+	Username string
+	Expires  time.Time
+}
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,31}$`)
+
+// IsReservedName reports whether a document name collides with the internal
+// collections.
+func IsReservedName(documentName string) bool {
+	return strings.HasPrefix(documentName, "_")
+}
+
+func validateUser(user User) (string, bool) {
+	if !usernamePattern.MatchString(user.Username) {
+		return "Username must be up to 32 lower case letters, digits, '.', '_' or '-'", false
+	}
+	switch user.Role {
+	case RoleAuthor, RoleReviewer, RoleApprover, RoleAdmin:
+	default:
+		return "Role must be author, reviewer, approver or admin", false
+	}
+	return "", true
+}
+
+func GetUser(username string) (string, User, bool) {
+	user := User{}
+	c := db.Collection(usersCollection)
+	if !c.Has(username) {
+		return "User Doesn't Exist", user, false
+	}
+	err := c.Get(username, &user)
+	if err != nil {
+		return err.Error(), user, false
+	}
+	return "", user, true
+}
+
+func GetAllUsers() (string, []User, bool) {
+	users := make([]User, 0)
+	c := db.Collection(usersCollection)
+	err := c.List(&users)
+	if err != nil {
+		return err.Error(), users, false
+	}
+	return "", users, true
+}
+
+// AddUser creates a user or updates an existing one. The password is only
+// changed if one is given, and is required for a new user.
+func AddUser(user User, password string) (string, bool) {
+	errMsg, ok := validateUser(user)
+	if !ok {
+		return errMsg, false
+	}
+	_, existing, found := GetUser(user.Username)
+	if found {
+		user.PasswordHash = existing.PasswordHash
+	}
+	if password != "" {
+		if len(password) < 8 {
+			return "Password must have at least 8 characters", false
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return err.Error(), false
+		}
+		user.PasswordHash = string(hash)
+	}
+	if user.PasswordHash == "" {
+		return "Password is empty", false
+	}
+	if found && existing.Role == RoleAdmin && user.Role != RoleAdmin && countAdmins() == 1 {
+		return "The last admin cannot lose the admin role", false
+	}
+	c := db.Collection(usersCollection)
+	err := c.Add(user.Username, user)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
+
+func DeleteUser(username string) (string, bool) {
+	errMsg, user, ok := GetUser(username)
+	if !ok {
+		return errMsg, false
+	}
+	if user.Role == RoleAdmin && countAdmins() == 1 {
+		return "The last admin cannot be deleted", false
+	}
+	c := db.Collection(usersCollection)
+	err := c.Delete(username)
+	if err != nil {
+		return err.Error(), false
+	}
+	deleteSessions(username)
+	return "", true
+}
+
+func countAdmins() int {
+	_, users, _ := GetAllUsers()
+	count := 0
+	for _, user := range users {
+		if user.Role == RoleAdmin {
+			count = count + 1
+		}
+	}
+	return count
+}
+
+// EnsureAdmin creates the user "admin" with the given password when there
+// are no users yet, so that a fresh installation can be logged into. A
+// random password is used instead when the given one is missing or too
+// short, and is returned so that it can be shown to the operator.
+func EnsureAdmin(password string) (string, string, bool) {
+	c := db.Collection(usersCollection)
+	if c.Exists() {
+		return "", "", true
+	}
+	generated := ""
+	if len(password) < 8 {
+		data := make([]byte, 9)
+		_, err := rand.Read(data)
+		if err != nil {
+			return err.Error(), "", false
+		}
+		generated = hex.EncodeToString(data)
+		password = generated
+	}
+	user := User{Username: "admin", FullName: "Administrator", Role: RoleAdmin}
+	errMsg, ok := AddUser(user, password)
+	return errMsg, generated, ok
+}
+
+// CheckPassword returns the user if the password matches.
+func CheckPassword(username string, password string) (User, bool) {
+	_, user, ok := GetUser(username)
+	if !ok {
+		return user, false
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		return user, false
+	}
+	return user, true
+}
+
+// AddSession starts a session for the user and returns it with its token.
+func AddSession(username string, duration time.Duration) (string, Session, bool) {
+	session := Session{}
+	// 24 bytes keep "_sessions/<token>" within the 64 byte key limit
+	data := make([]byte, 24)
+	_, err := rand.Read(data)
+	if err != nil {
+		return err.Error(), session, false
+	}
+	session.Token = hex.EncodeToString(data)
+	session.Username = username
+	session.Expires = time.Now().Add(duration)
+	c := db.Collection(sessionsCollection)
+	err = c.Add(session.Token, session)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), session, false
+	}
+	return "", session, true
+}
+
+// GetSession returns the session of a token, removing it if it has expired.
+func GetSession(token string) (string, Session, bool) {
+	session := Session{}
+	c := db.Collection(sessionsCollection)
+	if token == "" || !c.Has(token) {
+		return "Not Logged In", session, false
+	}
+	err := c.Get(token, &session)
+	if err != nil {
+		return err.Error(), session, false
+	}
+	if time.Now().After(session.Expires) {
+		c.Delete(token)
+		return "Session Expired", session, false
+	}
+	return "", session, true
+}
+
+func DeleteSession(token string) (string, bool) {
+	c := db.Collection(sessionsCollection)
+	if !c.Has(token) {
+		return "Not Logged In", false
+	}
+	err := c.Delete(token)
+	if err != nil {
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// deleteSessions ends every session of the user, and drops expired ones of
+// other users on the way.
+func deleteSessions(username string) {
+	sessions := make([]Session, 0)
+	c := db.Collection(sessionsCollection)
+	err := c.List(&sessions)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	now := time.Now()
+	for _, session := range sessions {
+		if session.Username == username || now.After(session.Expires) {
+			c.Delete(session.Token)
+		}
+	}
+}
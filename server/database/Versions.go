@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+)
+
+// VersionConflict is returned when a write is based on an older version of
+// what it replaces.
+const VersionConflict = "Version Conflict, the content was changed by someone else"
+
+// writeMutex serialises the versioned writes, the comments, the suggestions
+// and workflow transitions, so that reading, checking and writing happen
+// together.
+var writeMutex sync.Mutex
+
+// GetVersion returns how often the key of the document has been written
+// since versions were introduced, 0 if never.
+func GetVersion(documentName string, key string) int {
+	versions := make(map[string]int)
+	c := db.Collection(documentName)
+	if !c.Has("Versions") {
+		return 0
+	}
+	err := c.Get("Versions", &versions)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 0
+	}
+	return versions[key]
+}
+
+func increaseVersion(documentName string, key string) (string, bool) {
+	versions := make(map[string]int)
+	c := db.Collection(documentName)
+	if c.Has("Versions") {
+		err := c.Get("Versions", &versions)
+		if err != nil {
+			return err.Error(), false
+		}
+	}
+	versions[key] = versions[key] + 1
+	err := c.Add("Versions", versions)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), false
+	}
+	return "", true
+}
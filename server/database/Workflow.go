@@ -0,0 +1,219 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Workflow states of a document.
+const (
+	StateDraft     = "Draft"
+	StateSubmitted = "Submitted"
+	StateReviewed  = "Reviewed"
+	StateApproved  = "Approved"
+	StateReleased  = "Released"
+)
+
+// Workflow actions and the states they may be taken from.
+var workflowActions = map[string][]string{
+	"submit":  {StateDraft},
+	"review":  {StateSubmitted},
+	"approve": {StateReviewed},
+	"release": {StateApproved},
+	"return":  {StateSubmitted, StateReviewed, StateApproved},
+	"revise":  {StateReleased},
+}
+
+func getDefaultWorkflow() Workflow {
+	var workflow Workflow
+	workflow.State = StateDraft
+	workflow.History = make([]WorkflowTransition, 0)
+	return workflow
+}
+
+// GetWorkflow returns the workflow of the document. Documents created before
+// the workflow existed are drafts.
+func GetWorkflow(documentName string, user User) (string, Workflow, bool) {
+	if errMsg, ok := CheckAccess(documentName, user, AccessView); !ok {
+		return errMsg, getDefaultWorkflow(), false
+	}
+	return getWorkflow(documentName)
+}
+
+func getWorkflow(documentName string) (string, Workflow, bool) {
+	workflow := getDefaultWorkflow()
+	c := db.Collection(documentName)
+	if errMsg, ok := checkDocument(documentName); !ok {
+		return errMsg, workflow, false
+	}
+	if !c.Has("Workflow") {
+		return "", workflow, true
+	}
+	err := c.Get("Workflow", &workflow)
+	if err != nil {
+		return err.Error(), workflow, false
+	}
+	return "", workflow, true
+}
+
+// checkDraft refuses changes to the content of a document that has left the
+// Draft state. Callers hold writeMutex, so that no transition happens
+// between the check and the write.
+func checkDraft(documentName string) (string, bool) {
+	errMsg, workflow, ok := getWorkflow(documentName)
+	if !ok {
+		return errMsg, false
+	}
+	if workflow.State != StateDraft {
+		return "Document is " + workflow.State + " and cannot be edited, return it to Draft first", false
+	}
+	return "", true
+}
+
+func matchesName(user User, name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	return strings.EqualFold(name, strings.TrimSpace(user.FullName)) || strings.EqualFold(name, user.Username)
+}
+
+// sameApprover reports whether the two approver names are one person, the
+// same name or both names of one user.
+func sameApprover(first string, second string) bool {
+	if strings.TrimSpace(first) == "" || strings.TrimSpace(second) == "" {
+		return false
+	}
+	if strings.EqualFold(strings.TrimSpace(first), strings.TrimSpace(second)) {
+		return true
+	}
+	_, users, _ := GetAllUsers()
+	return slices.ContainsFunc(users, func(user User) bool {
+		return matchesName(user, first) && matchesName(user, second)
+	})
+}
+
+func hasRole(user User, role string) bool {
+	return user.Role == role || user.Role == RoleAdmin
+}
+
+// Transition takes a workflow action on the document for the user:
+//   - submit: Draft to Submitted, by an editor of the document.
+//   - review: Submitted to Reviewed, by the reviewer named in DocumentDetails.
+//   - approve: records the approval of one of the named approvers. The
+//     document is Approved once every named approver has approved it.
+//   - release: Approved to Released, by an editor of the document.
+//   - return: back to Draft, by an editor, the reviewer or an approver.
+//   - revise: Released to Draft for the next issue, by an editor.
+//
+// The reviewer and approvers are matched on the full name or username of the
+// user. The two approvals must come from different users. Returning and
+// revising need a comment and clear the review and approvals. The workflow
+// is read, checked and written under writeMutex, so that concurrent
+// transitions and the Draft check of writes see each other.
+func Transition(documentName string, action string, user User, comment string) (string, Workflow, bool) {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	errMsg, ok := CheckAccess(documentName, user, AccessView)
+	if !ok {
+		return errMsg, getDefaultWorkflow(), false
+	}
+	errMsg, workflow, ok := getWorkflow(documentName)
+	if !ok {
+		return errMsg, workflow, false
+	}
+	errMsg, details, ok := getDocumentDetails(documentName)
+	if !ok {
+		return errMsg, workflow, false
+	}
+	from, ok := workflowActions[action]
+	if !ok {
+		return "Unknown action " + action, workflow, false
+	}
+	if !slices.Contains(from, workflow.State) {
+		return "Cannot " + action + " a document that is " + workflow.State, workflow, false
+	}
+	_, isEditor := CheckAccess(documentName, user, AccessEdit)
+	isEditor = isEditor && hasRole(user, RoleAuthor)
+	isReviewer := hasRole(user, RoleReviewer) && matchesName(user, details.ReviewedByName)
+	isFirstApprover := hasRole(user, RoleApprover) && matchesName(user, details.FirstApproverName)
+	isSecondApprover := hasRole(user, RoleApprover) && matchesName(user, details.SecondApproverName)
+	now := time.Now().Format(timeFormat)
+
+	state := workflow.State
+	switch action {
+	case "submit":
+		if !isEditor {
+			return "Only an editor of the document can submit it", workflow, false
+		}
+		if strings.TrimSpace(details.ReviewedByName) == "" || strings.TrimSpace(details.FirstApproverName) == "" {
+			return "Document Details must name the reviewer and the first approver", workflow, false
+		}
+		if sameApprover(details.FirstApproverName, details.SecondApproverName) {
+			return "The first and second approver must be different people", workflow, false
+		}
+		state = StateSubmitted
+	case "review":
+		if !isReviewer {
+			return "Only " + details.ReviewedByName + " can review the document", workflow, false
+		}
+		workflow.ReviewedBy = user.Username
+		workflow.ReviewedTime = now
+		state = StateReviewed
+	case "approve":
+		if isFirstApprover && workflow.FirstApprovedBy == "" && workflow.SecondApprovedBy != user.Username {
+			workflow.FirstApprovedBy = user.Username
+			workflow.FirstApprovedTime = now
+		} else if isSecondApprover && workflow.SecondApprovedBy == "" && workflow.FirstApprovedBy != user.Username {
+			workflow.SecondApprovedBy = user.Username
+			workflow.SecondApprovedTime = now
+		} else if isFirstApprover || isSecondApprover {
+			return "Already approved by " + user.Username + ", the second approval must come from someone else", workflow, false
+		} else {
+			return "Only the approvers named in Document Details can approve the document", workflow, false
+		}
+		secondNeeded := strings.TrimSpace(details.SecondApproverName) != ""
+		if workflow.FirstApprovedBy != "" && (!secondNeeded || workflow.SecondApprovedBy != "") {
+			state = StateApproved
+		}
+	case "release":
+		if !isEditor {
+			return "Only an editor of the document can release it", workflow, false
+		}
+		state = StateReleased
+	case "return", "revise":
+		if !isEditor && (action == "revise" || !(isReviewer || isFirstApprover || isSecondApprover)) {
+			return "Not allowed to " + action + " the document", workflow, false
+		}
+		if strings.TrimSpace(comment) == "" {
+			return "A comment is needed to " + action + " the document", workflow, false
+		}
+		workflow.ReviewedBy = ""
+		workflow.ReviewedTime = ""
+		workflow.FirstApprovedBy = ""
+		workflow.FirstApprovedTime = ""
+		workflow.SecondApprovedBy = ""
+		workflow.SecondApprovedTime = ""
+		state = StateDraft
+	}
+
+	var transition WorkflowTransition
+	transition.Action = action
+	transition.From = workflow.State
+	transition.To = state
+	transition.Username = user.Username
+	transition.Time = now
+	transition.Comment = comment
+	workflow.History = append(workflow.History, transition)
+	workflow.State = state
+
+	c := db.Collection(documentName)
+	err := c.Add("Workflow", workflow)
+	if err != nil {
+		fmt.Println(err.Error())
+		return err.Error(), workflow, false
+	}
+	return "", workflow, true
+}
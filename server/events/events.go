@@ -0,0 +1,83 @@
+// Package events broadcasts changes to documents to the open browser
+// sessions, which receive them as server-sent events from /events.
+package events
+
+import (
+	"intDocument/server/database"
+	"sync"
+	"time"
+)
+
+// Event types.
+const (
+	ContentSaved     = "content"
+	DetailsChanged   = "details"
+	DocumentCreated  = "created"
+	DocumentCopied   = "copied"
+	DocumentDeleted  = "deleted"
+	DocumentRestored = "restored"
+	DocumentPurged   = "purged"
+	CompileFinished  = "compiled"
+	CommentAdded     = "comment"
+)
+
+type Event struct {
+	Type         string
+	DocumentName string
+	Subsection   string
+	Username     string
+	Time         string
+	Message      string
+}
+
+type subscriber struct {
+	user    database.User
+	channel chan Event
+}
+
+var mutex sync.Mutex
+var subscribers = make(map[chan Event]subscriber)
+
+// Subscribe returns the channel on which the events the user may see are
+// delivered. It must be given back with Unsubscribe.
+func Subscribe(user database.User) chan Event {
+	channel := make(chan Event, 32)
+	mutex.Lock()
+	defer mutex.Unlock()
+	subscribers[channel] = subscriber{user: user, channel: channel}
+	return channel
+}
+
+func Unsubscribe(channel chan Event) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(subscribers, channel)
+}
+
+// MayView reports whether the user may see the event. A purged document has
+// no access list left, so only admins, who manage the trash, see those.
+func MayView(event Event, user database.User) bool {
+	if event.Type == DocumentPurged {
+		return user.Role == database.RoleAdmin
+	}
+	_, ok := database.CheckAccess(event.DocumentName, user, database.AccessView)
+	return ok
+}
+
+// Publish sends the event to every subscriber who may view the document.
+// A subscriber that is not keeping up misses the event rather than holding
+// up the request that published it.
+func Publish(event Event) {
+	event.Time = time.Now().Format(time.RFC3339)
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, s := range subscribers {
+		if !MayView(event, s.user) {
+			continue
+		}
+		select {
+		case s.channel <- event:
+		default:
+		}
+	}
+}
@@ -3,15 +3,15 @@ package handlers
 import (
 	"encoding/base64"
 	"fmt"
+	"intDocument/server/auth"
 	"intDocument/server/database"
+	"intDocument/server/events"
+	"intDocument/server/health"
 	"intDocument/server/llm"
 	"intDocument/server/pdf"
-	"io"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,17 +21,23 @@ type ProcessDocResponse struct {
 	Message string `json:"message"`
 }
 
+// publish sends an event about a part of the document filled in from the
+// design document on behalf of the logged in user.
+func publish(c *gin.Context, eventType string, documentName string, subsection string) {
+	var event events.Event
+	event.Type = eventType
+	event.DocumentName = documentName
+	event.Subsection = subsection
+	event.Username = auth.GetUser(c).Username
+	event.Message = "Filled in from the Design Document"
+	events.Publish(event)
+}
+
 func ProcessDesignDoc(c *gin.Context) {
 	var response ProcessDocResponse
 
-	// 1. Get Document ID and File
-	docID := c.PostForm("id")
-	if docID == "" {
-		response.OK = false
-		response.Message = "Missing Document ID"
-		c.IndentedJSON(http.StatusBadRequest, response)
-		return
-	}
+	// 1. Get Document Name and File
+	docID := auth.GetWorkID(c)
 
 	documentName := c.PostForm("name")
 	if documentName == "" {
@@ -62,6 +68,14 @@ func ProcessDesignDoc(c *gin.Context) {
 		return
 	}
 
+	msg, ok := health.LLMReady()
+	if !ok {
+		response.OK = false
+		response.Message = msg
+		c.IndentedJSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
 	llmClient := llm.NewOllamaClient()
 
 	// 3. Parse TOC
@@ -94,11 +108,12 @@ func ProcessDesignDoc(c *gin.Context) {
 				content.ContentType = []string{"Text"}
 				content.Value = []string{summary}
 
-				msg, ok := database.AddContent(documentName, "introduction", content)
+				msg, ok := database.AddContent(documentName, "introduction", content, auth.GetUser(c))
 				if !ok {
 					processErrors = append(processErrors, "Failed to update Introduction DB: "+msg)
 				} else {
 					successCount++
+					publish(c, events.ContentSaved, documentName, "introduction")
 				}
 			} else {
 				processErrors = append(processErrors, "Introduction Summarization failed: "+err.Error())
@@ -116,7 +131,7 @@ func ProcessDesignDoc(c *gin.Context) {
 		if err == nil && text != "" {
 			specs, err := llmClient.ExtractSpecifications(text)
 			if err == nil {
-				msg, existingDetails, ok := database.GetSubsystemDetails(documentName)
+				msg, existingDetails, ok := database.GetSubsystemDetails(documentName, auth.GetUser(c))
 				if !ok {
 					// Even if get fails, we might want to overwrite or create new?
 					// But usually Get fails if DB error. We'll init a empty struct if not found.
@@ -138,11 +153,12 @@ func ProcessDesignDoc(c *gin.Context) {
 					existingDetails.SatelliteName = val
 				}
 
-				msg, ok = database.AddSubsystemDetails(documentName, existingDetails)
+				msg, ok = database.AddSubsystemDetails(documentName, existingDetails, auth.GetUser(c))
 				if !ok {
 					processErrors = append(processErrors, "Failed to update Subsystem DB: "+msg)
 				} else {
 					successCount++
+					publish(c, events.DetailsChanged, documentName, "SubsystemDetails")
 				}
 			} else {
 				processErrors = append(processErrors, "Specification extraction failed: "+err.Error())
@@ -171,11 +187,12 @@ func ProcessDesignDoc(c *gin.Context) {
 					content.Captions = []string{"Block Diagram extracted from Design Document"}
 					content.Landscape = []bool{false}
 
-					msg, ok := database.AddContent(documentName, "block_diagram", content)
+					msg, ok := database.AddContent(documentName, "block_diagram", content, auth.GetUser(c))
 					if !ok {
 						processErrors = append(processErrors, "Failed to add Block Diagram to DB: "+msg)
 					} else {
 						successCount++
+						publish(c, events.ContentSaved, documentName, "block_diagram")
 					}
 				} else {
 					processErrors = append(processErrors, "Failed to read extracted image.")
@@ -0,0 +1,180 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"intDocument/server/config"
+	"intDocument/server/database"
+	"intDocument/server/typst"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const logoPath = "resources/logo.png"
+
+type Dependency struct {
+	Name    string
+	OK      bool
+	Status  string
+	Version string
+	Message string
+}
+
+type Report struct {
+	OK           bool
+	Dependencies []Dependency
+}
+
+// Check inspects every external dependency of the server.
+func Check() Report {
+	var report Report
+	report.Dependencies = make([]Dependency, 0)
+	report.Dependencies = append(report.Dependencies, checkCommand("Typst", "typst", "--version"))
+	report.Dependencies = append(report.Dependencies, checkCommand("ImageMagick", "convert", "-version"))
+	report.Dependencies = append(report.Dependencies, checkLogo())
+	report.Dependencies = append(report.Dependencies, checkOfflineResources())
+	report.Dependencies = append(report.Dependencies, checkOllama())
+	report.Dependencies = append(report.Dependencies, checkDatabase())
+
+	report.OK = true
+	for _, dependency := range report.Dependencies {
+		if !dependency.OK {
+			report.OK = false
+		}
+	}
+	return report
+}
+
+func checkCommand(name string, command string, versionFlag string) Dependency {
+	var dependency Dependency
+	dependency.Name = name
+	path, err := exec.LookPath(command)
+	if err != nil {
+		dependency.Status = "Missing"
+		dependency.Message = command + " is not installed or not in PATH"
+		return dependency
+	}
+	output, err := exec.Command(path, versionFlag).CombinedOutput()
+	if err != nil {
+		dependency.Status = "Error"
+		dependency.Message = "Cannot run " + path + ": " + err.Error()
+		return dependency
+	}
+	dependency.OK = true
+	dependency.Status = "OK"
+	dependency.Version = strings.TrimSpace(strings.Split(string(output), "\n")[0])
+	dependency.Message = path
+	return dependency
+}
+
+func checkLogo() Dependency {
+	var dependency Dependency
+	dependency.Name = "Logo"
+	path, _ := filepath.Abs(logoPath)
+	_, err := os.Stat(logoPath)
+	if err != nil {
+		dependency.Status = "Missing"
+		dependency.Message = path + " not found, start the server from the directory containing resources/"
+		return dependency
+	}
+	dependency.OK = true
+	dependency.Status = "OK"
+	dependency.Message = path
+	return dependency
+}
+
+func checkOfflineResources() Dependency {
+	var dependency Dependency
+	dependency.Name = "Typst Packages and Fonts"
+	problems := typst.CheckOfflineResources()
+	if len(problems) > 0 {
+		dependency.Status = "Incomplete"
+		dependency.Message = strings.Join(problems, "; ")
+		return dependency
+	}
+	dependency.OK = true
+	dependency.Status = "OK"
+	dependency.Message = config.Config.TypstPackagePath + ", " + config.Config.TypstFontPath
+	return dependency
+}
+
+func checkOllama() Dependency {
+	var dependency Dependency
+	dependency.Name = "Ollama"
+	client := http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get(config.Config.OllamaURL + "/api/version")
+	if err != nil {
+		dependency.Status = "Unreachable"
+		dependency.Message = "Cannot reach " + config.Config.OllamaURL + ": " + err.Error()
+		return dependency
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		dependency.Status = "Error"
+		dependency.Message = config.Config.OllamaURL + " returned " + resp.Status
+		return dependency
+	}
+	var version struct {
+		Version string `json:"version"`
+	}
+	json.NewDecoder(resp.Body).Decode(&version)
+	dependency.OK = true
+	dependency.Status = "OK"
+	dependency.Version = version.Version
+	dependency.Message = config.Config.OllamaURL + " (model " + config.Config.OllamaModel + ")"
+	return dependency
+}
+
+func checkDatabase() Dependency {
+	var dependency Dependency
+	dependency.Name = "Database"
+	msg, ok := database.CheckWritable()
+	if !ok {
+		dependency.Status = "Error"
+		dependency.Message = msg
+		return dependency
+	}
+	dependency.OK = true
+	dependency.Status = "OK"
+	dependency.Message = config.Config.BasePath + config.Config.DatabasePath
+	return dependency
+}
+
+// CompileReady reports whether documents can be compiled to PDF.
+func CompileReady() (string, bool) {
+	_, err := exec.LookPath("typst")
+	if err != nil {
+		return "PDF generation is unavailable: typst is not installed on the server", false
+	}
+	_, err = exec.LookPath("convert")
+	if err != nil {
+		return "PDF generation is unavailable: ImageMagick convert is not installed on the server", false
+	}
+	_, err = os.Stat(logoPath)
+	if err != nil {
+		return "PDF generation is unavailable: " + logoPath + " is missing on the server", false
+	}
+	return "", true
+}
+
+// LLMReady reports whether the Ollama server used for design document
+// processing can be reached.
+func LLMReady() (string, bool) {
+	dependency := checkOllama()
+	if !dependency.OK {
+		return "Design document processing is unavailable: " + dependency.Message, false
+	}
+	return "", true
+}
+
+// PrintReport prints the startup diagnostic.
+func PrintReport(report Report) {
+	fmt.Println("Dependency check:")
+	for _, dependency := range report.Dependencies {
+		fmt.Printf("  %-26s %-12s %s %s\n", dependency.Name, dependency.Status, dependency.Version, dependency.Message)
+	}
+}
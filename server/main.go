@@ -7,9 +7,11 @@ import (
 	"intDocument/server/client"
 	"intDocument/server/config"
 	"intDocument/server/database"
+	"intDocument/server/health"
 	"io/fs"
 	"log"
 	"mime"
+	"time"
 )
 
 var Version string
@@ -36,6 +38,20 @@ func main() {
 	if !ok {
 		log.Fatal("Cannot connect to Database")
 	}
+	msg, generated, ok := database.EnsureAdmin(config.Config.AdminPassword)
+	if !ok {
+		fmt.Println("Error when creating the admin user", msg)
+	} else if generated != "" {
+		fmt.Println("AdminPassword is missing or shorter than 8 characters, created user admin with password", generated)
+	}
+	// Documents whose time in the trash is up are purged now and every hour.
+	client.PurgeExpiredDocuments()
+	go func() {
+		for range time.Tick(time.Hour) {
+			client.PurgeExpiredDocuments()
+		}
+	}()
+	health.PrintReport(health.Check())
 
 	// Get the subtree of the embedded files, so we can serve it from the root.
 	webFS, err := fs.Sub(embeddedFiles, "web")
@@ -0,0 +1,78 @@
+package sequence
+
+// Schema is the JSON schema of the sequence file. The YAML export has the
+// same structure.
+const Schema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "ist-sequence/1",
+  "title": "IST checkout sequence",
+  "description": "Test procedures of an IST document as sequences of TC sends, waits and TM verifications.",
+  "type": "object",
+  "required": ["format", "document", "generated", "sequences"],
+  "properties": {
+    "format": { "const": "ist-sequence/1" },
+    "document": { "type": "string", "description": "Name of the IST document." },
+    "generated": { "type": "string", "format": "date-time" },
+    "sequences": {
+      "type": "array",
+      "items": { "$ref": "#/$defs/sequence" }
+    }
+  },
+  "$defs": {
+    "sequence": {
+      "type": "object",
+      "required": ["procedure", "steps"],
+      "properties": {
+        "procedure": { "type": "string" },
+        "title": { "type": "string" },
+        "steps": {
+          "type": "array",
+          "items": { "$ref": "#/$defs/step" }
+        }
+      }
+    },
+    "step": {
+      "type": "object",
+      "required": ["step", "action", "commands"],
+      "properties": {
+        "step": { "type": "integer", "minimum": 1 },
+        "action": { "type": "string" },
+        "remarks": { "type": "string" },
+        "commands": {
+          "type": "array",
+          "description": "Run in order: TC sends, then the wait, then TM verifications.",
+          "items": { "$ref": "#/$defs/command" }
+        }
+      }
+    },
+    "command": {
+      "type": "object",
+      "required": ["type"],
+      "properties": {
+        "type": { "enum": ["send_tc", "wait", "verify_tm"] },
+        "mnemonic": { "type": "string" },
+        "code": { "type": "string", "description": "TC code from the telecommand list." },
+        "seconds": { "type": "number", "exclusiveMinimum": 0 },
+        "expected": { "type": "string", "description": "Expected value or status of the TM." },
+        "tolerance": { "type": "string", "description": "Absolute (0.5, ±0.5) or relative (5%) tolerance." },
+        "lowerLimit": { "type": "string" },
+        "upperLimit": { "type": "string" }
+      },
+      "allOf": [
+        {
+          "if": { "properties": { "type": { "const": "send_tc" } } },
+          "then": { "required": ["mnemonic"] }
+        },
+        {
+          "if": { "properties": { "type": { "const": "wait" } } },
+          "then": { "required": ["seconds"] }
+        },
+        {
+          "if": { "properties": { "type": { "const": "verify_tm" } } },
+          "then": { "required": ["mnemonic"] }
+        }
+      ]
+    }
+  }
+}
+`
@@ -0,0 +1,156 @@
+// Package sequence converts the structured test procedures of a document
+// into checkout sequences that automation tools can run. The format is
+// described by the JSON schema in Schema.
+package sequence
+
+import (
+	"encoding/json"
+	"fmt"
+	"intDocument/server/database"
+	"intDocument/server/tmtc"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const FormatVersion = "ist-sequence/1"
+
+type Command struct {
+	Type       string  `json:"type" yaml:"type"`
+	Mnemonic   string  `json:"mnemonic,omitempty" yaml:"mnemonic,omitempty"`
+	Code       string  `json:"code,omitempty" yaml:"code,omitempty"`
+	Seconds    float64 `json:"seconds,omitempty" yaml:"seconds,omitempty"`
+	Expected   string  `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Tolerance  string  `json:"tolerance,omitempty" yaml:"tolerance,omitempty"`
+	LowerLimit string  `json:"lowerLimit,omitempty" yaml:"lowerLimit,omitempty"`
+	UpperLimit string  `json:"upperLimit,omitempty" yaml:"upperLimit,omitempty"`
+}
+
+type Step struct {
+	Step     int       `json:"step" yaml:"step"`
+	Action   string    `json:"action" yaml:"action"`
+	Remarks  string    `json:"remarks,omitempty" yaml:"remarks,omitempty"`
+	Commands []Command `json:"commands" yaml:"commands"`
+}
+
+type Sequence struct {
+	Procedure string `json:"procedure" yaml:"procedure"`
+	Title     string `json:"title,omitempty" yaml:"title,omitempty"`
+	Steps     []Step `json:"steps" yaml:"steps"`
+}
+
+type File struct {
+	Format    string     `json:"format" yaml:"format"`
+	Document  string     `json:"document" yaml:"document"`
+	Generated string     `json:"generated" yaml:"generated"`
+	Sequences []Sequence `json:"sequences" yaml:"sequences"`
+}
+
+var waitPattern = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([a-zA-Z]*)$`)
+
+// ParseWaitTime returns a wait time such as "5", "5 s", "500ms" or "2 min"
+// in seconds.
+func ParseWaitTime(wait string) (float64, error) {
+	wait = strings.TrimSpace(wait)
+	if wait == "" {
+		return 0, nil
+	}
+	match := waitPattern.FindStringSubmatch(wait)
+	if match == nil {
+		return 0, fmt.Errorf("wait time %q is not understood", wait)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	switch strings.ToLower(match[2]) {
+	case "", "s", "sec", "secs", "second", "seconds":
+		return value, nil
+	case "ms":
+		return value / 1000, nil
+	case "m", "min", "mins", "minute", "minutes":
+		return value * 60, nil
+	case "h", "hr", "hrs", "hour", "hours":
+		return value * 3600, nil
+	default:
+		return 0, fmt.Errorf("unit of wait time %q is not understood", wait)
+	}
+}
+
+// Build converts the procedures into sequences. Every step sends its TC,
+// waits and then verifies its TM, in that order. The problems returned are
+// references to undefined mnemonics and wait times that cannot be read.
+func Build(documentName string, procedures []database.Procedure, telecommands []database.Telecommand, parameters []database.TelemetryParameter) (File, []string) {
+	file := File{}
+	file.Format = FormatVersion
+	file.Document = documentName
+	file.Generated = time.Now().UTC().Format(time.RFC3339)
+	file.Sequences = make([]Sequence, 0)
+	problems := make([]string, 0)
+
+	tcs := make(map[string]database.Telecommand)
+	for _, tc := range telecommands {
+		tcs[strings.ToUpper(tc.Mnemonic)] = tc
+	}
+	tms := make(map[string]database.TelemetryParameter)
+	for _, tm := range parameters {
+		tms[strings.ToUpper(tm.Mnemonic)] = tm
+	}
+
+	for _, procedure := range procedures {
+		sequence := Sequence{Procedure: procedure.Name, Title: procedure.Title}
+		sequence.Steps = make([]Step, 0)
+		for i, procedureStep := range procedure.Steps {
+			where := fmt.Sprintf("%s step %d", procedure.Name, i+1)
+			step := Step{Step: i + 1, Action: procedureStep.Action, Remarks: procedureStep.Remarks}
+			step.Commands = make([]Command, 0)
+			for _, name := range tmtc.SplitMnemonics(procedureStep.Telecommand) {
+				tc, ok := tcs[strings.ToUpper(name)]
+				if !ok {
+					problems = append(problems, where+": Telecommand "+name+" is not defined")
+					continue
+				}
+				step.Commands = append(step.Commands, Command{Type: "send_tc", Mnemonic: tc.Mnemonic, Code: tc.Code})
+			}
+			seconds, err := ParseWaitTime(procedureStep.WaitTime)
+			if err != nil {
+				problems = append(problems, where+": "+err.Error())
+			} else if seconds > 0 {
+				step.Commands = append(step.Commands, Command{Type: "wait", Seconds: seconds})
+			}
+			for j, name := range tmtc.SplitMnemonics(procedureStep.Telemetry) {
+				tm, ok := tms[strings.ToUpper(name)]
+				if !ok {
+					problems = append(problems, where+": Telemetry "+name+" is not defined")
+					continue
+				}
+				command := Command{Type: "verify_tm", Mnemonic: tm.Mnemonic, LowerLimit: tm.LowerLimit, UpperLimit: tm.UpperLimit}
+				// The expected value belongs to the first TM of the step,
+				// the others are only checked against their limits.
+				if j == 0 {
+					command.Expected = procedureStep.ExpectedValue
+					command.Tolerance = procedureStep.Tolerance
+				}
+				step.Commands = append(step.Commands, command)
+			}
+			sequence.Steps = append(sequence.Steps, step)
+		}
+		file.Sequences = append(file.Sequences, sequence)
+	}
+	return file, problems
+}
+
+// Encode writes the sequence file as "json" or "yaml".
+func Encode(file File, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "json", "":
+		return json.MarshalIndent(file, "", "  ")
+	case "yaml", "yml":
+		return yaml.Marshal(file)
+	default:
+		return nil, fmt.Errorf("unknown format %s, expected json or yaml", format)
+	}
+}
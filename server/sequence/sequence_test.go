@@ -0,0 +1,140 @@
+package sequence
+
+import (
+	"encoding/json"
+	"intDocument/server/database"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestParseWaitTime(t *testing.T) {
+	tests := []struct {
+		wait    string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"5", 5, false},
+		{"5 s", 5, false},
+		{"2.5sec", 2.5, false},
+		{"500ms", 0.5, false},
+		{"2 min", 120, false},
+		{"1 h", 3600, false},
+		{".5 s", 0.5, false},
+		{"5 days", 0, true},
+		{"soon", 0, true},
+		{"-5", 0, true},
+	}
+	for _, test := range tests {
+		got, err := ParseWaitTime(test.wait)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseWaitTime(%q) error = %v, want error %v", test.wait, err, test.wantErr)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("ParseWaitTime(%q) = %v, want %v", test.wait, got, test.want)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	telecommands := []database.Telecommand{
+		{Mnemonic: "PL_ON", Code: "0x10", Description: "Payload on"},
+	}
+	parameters := []database.TelemetryParameter{
+		{Mnemonic: "PL_STS", Channel: "D01"},
+		{Mnemonic: "BUS_V", Channel: "A01", LowerLimit: "26", UpperLimit: "33"},
+	}
+	tests := []struct {
+		name     string
+		step     database.ProcedureStep
+		commands []Command
+		problems []string
+	}{
+		{
+			name: "send, wait and verify",
+			step: database.ProcedureStep{Action: "On", Telecommand: "pl_on", Telemetry: "PL_STS", ExpectedValue: "ON", WaitTime: "2 s"},
+			commands: []Command{
+				{Type: "send_tc", Mnemonic: "PL_ON", Code: "0x10"},
+				{Type: "wait", Seconds: 2},
+				{Type: "verify_tm", Mnemonic: "PL_STS", Expected: "ON"},
+			},
+			problems: []string{},
+		},
+		{
+			name: "leading separator keeps the expected value on the first TM",
+			step: database.ProcedureStep{Action: "Check", Telemetry: " , BUS_V, PL_STS", ExpectedValue: "28", Tolerance: "0.5"},
+			commands: []Command{
+				{Type: "verify_tm", Mnemonic: "BUS_V", Expected: "28", Tolerance: "0.5", LowerLimit: "26", UpperLimit: "33"},
+				{Type: "verify_tm", Mnemonic: "PL_STS"},
+			},
+			problems: []string{},
+		},
+		{
+			name:     "undefined mnemonics and bad wait time",
+			step:     database.ProcedureStep{Action: "Bad", Telecommand: "PL_OFF", Telemetry: "HEATER_I", WaitTime: "later"},
+			commands: []Command{},
+			problems: []string{
+				"P-1 step 1: Telecommand PL_OFF is not defined",
+				"P-1 step 1: wait time \"later\" is not understood",
+				"P-1 step 1: Telemetry HEATER_I is not defined",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			procedure := database.Procedure{Name: "P-1", Steps: []database.ProcedureStep{test.step}}
+			file, problems := Build("Doc", []database.Procedure{procedure}, telecommands, parameters)
+			if file.Format != FormatVersion || file.Document != "Doc" || len(file.Sequences) != 1 {
+				t.Fatalf("unexpected file %+v", file)
+			}
+			commands := file.Sequences[0].Steps[0].Commands
+			if !reflect.DeepEqual(commands, test.commands) {
+				t.Errorf("commands = %+v, want %+v", commands, test.commands)
+			}
+			if !reflect.DeepEqual(problems, test.problems) {
+				t.Errorf("problems = %q, want %q", problems, test.problems)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	file := File{
+		Format:   FormatVersion,
+		Document: "Doc",
+		Sequences: []Sequence{{Procedure: "P-1", Steps: []Step{{Step: 1, Action: "On", Commands: []Command{
+			{Type: "send_tc", Mnemonic: "PL_ON", Code: "0x10"},
+			{Type: "wait", Seconds: 2.5},
+		}}}}},
+	}
+	tests := []struct {
+		format    string
+		unmarshal func([]byte, any) error
+	}{
+		{"json", json.Unmarshal},
+		{"", json.Unmarshal},
+		{"YAML", yaml.Unmarshal},
+		{"yml", yaml.Unmarshal},
+	}
+	for _, test := range tests {
+		data, err := Encode(file, test.format)
+		if err != nil {
+			t.Fatalf("Encode(%q) error = %v", test.format, err)
+		}
+		var decoded File
+		err = test.unmarshal(data, &decoded)
+		if err != nil {
+			t.Fatalf("cannot read back %q output: %v", test.format, err)
+		}
+		if !reflect.DeepEqual(decoded, file) {
+			t.Errorf("Encode(%q) read back as %+v, want %+v", test.format, decoded, file)
+		}
+	}
+	_, err := Encode(file, "xml")
+	if err == nil {
+		t.Errorf("Encode(\"xml\") did not fail")
+	}
+}
@@ -0,0 +1,263 @@
+package tmtc
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type LogEvaluation struct {
+	Samples     int
+	Steps       []database.StepResult
+	OutOfLimits []database.OutOfLimitSample
+}
+
+// EvaluateLog compares a telemetry log of the checkout system with the
+// procedure and the telemetry list. The log is a CSV file with a Time column,
+// an optional Step column and one column per TM mnemonic.
+//
+// Every sample is checked against the limits and status words of its
+// parameter. A step that names a TM and an expected value is evaluated with
+// the last sample of its first TM logged for the step (or in the whole log if
+// there is no Step column). It passes if the value matches within tolerance
+// and none of its TM went out of limits during the step. Without a Step
+// column, or with a Step cell that is not a step number, a sample out of
+// limits cannot be attributed to a step, so it is reported with step 0 and
+// only noted in the remarks of the steps using its TM. Samples out of limits
+// are reported in the order of the rows, and within a row in the order of
+// the columns.
+func EvaluateLog(data []byte, procedure database.Procedure, parameters []database.TelemetryParameter) (LogEvaluation, error) {
+	var evaluation LogEvaluation
+	evaluation.Steps = make([]database.StepResult, 0)
+	evaluation.OutOfLimits = make([]database.OutOfLimitSample, 0)
+
+	rows, err := ReadTable(data, "csv")
+	if err != nil {
+		return evaluation, err
+	}
+	if len(rows) < 2 {
+		return evaluation, fmt.Errorf("log has no samples")
+	}
+	columns := getColumns(rows[0], map[string][]string{
+		"Time": {"time", "timestamp", "utc"},
+		"Step": {"step", "stepno"},
+	})
+	if _, ok := columns["Time"]; !ok {
+		return evaluation, fmt.Errorf("log has no Time column")
+	}
+	_, hasStep := columns["Step"]
+
+	definitions := make(map[string]database.TelemetryParameter)
+	for _, tm := range parameters {
+		definitions[strings.ToUpper(tm.Mnemonic)] = tm
+	}
+	mnemonics := make(map[int]string)
+	indices := make([]int, 0)
+	for i, name := range rows[0] {
+		if i == columns["Time"] || (hasStep && i == columns["Step"]) {
+			continue
+		}
+		mnemonics[i] = strings.ToUpper(strings.TrimSpace(name))
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	// last holds the last value of each mnemonic per step, step 0 being
+	// the whole log. failed marks the mnemonics that left their limits,
+	// step 0 holding those out of limits in samples without a step.
+	last := make(map[int]map[string]string)
+	failed := make(map[int]map[string]bool)
+	record := func(table map[int]map[string]string, step int, mnemonic string, value string) {
+		if table[step] == nil {
+			table[step] = make(map[string]string)
+		}
+		table[step][mnemonic] = value
+	}
+	for _, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		evaluation.Samples = evaluation.Samples + 1
+		step := 0
+		if hasStep {
+			number, err := strconv.Atoi(strings.TrimSpace(getCell(row, columns, "Step")))
+			if err == nil && number > 0 {
+				step = number
+			}
+		}
+		for _, index := range indices {
+			mnemonic := mnemonics[index]
+			if index >= len(row) || strings.TrimSpace(row[index]) == "" {
+				continue
+			}
+			value := strings.TrimSpace(row[index])
+			record(last, 0, mnemonic, value)
+			record(last, step, mnemonic, value)
+			tm, ok := definitions[mnemonic]
+			if !ok || withinLimits(value, tm) {
+				continue
+			}
+			if failed[step] == nil {
+				failed[step] = make(map[string]bool)
+			}
+			failed[step][mnemonic] = true
+			evaluation.OutOfLimits = append(evaluation.OutOfLimits, database.OutOfLimitSample{
+				Time:       getCell(row, columns, "Time"),
+				Step:       step,
+				Mnemonic:   tm.Mnemonic,
+				Value:      value,
+				LowerLimit: tm.LowerLimit,
+				UpperLimit: tm.UpperLimit,
+			})
+		}
+	}
+
+	for i, step := range procedure.Steps {
+		stepNo := i + 1
+		names := SplitMnemonics(strings.ToUpper(step.Telemetry))
+		if len(names) == 0 || strings.TrimSpace(step.ExpectedValue) == "" {
+			continue
+		}
+		window := 0
+		if hasStep {
+			window = stepNo
+		}
+		observed := make([]string, 0)
+		pass := true
+		missing := false
+		unattributed := false
+		for j, name := range names {
+			value, ok := last[window][name]
+			if !ok {
+				missing = true
+				continue
+			}
+			if len(names) > 1 {
+				observed = append(observed, name+"="+value)
+			} else {
+				observed = append(observed, value)
+			}
+			if j == 0 && !matchesExpected(value, step.ExpectedValue, step.Tolerance, definitions[name]) {
+				pass = false
+			}
+			if hasStep && failed[window][name] {
+				pass = false
+			}
+			if failed[0][name] {
+				unattributed = true
+			}
+		}
+		if missing && len(observed) == 0 {
+			continue
+		}
+		result := database.StepResult{Step: stepNo, Observed: strings.Join(observed, ", ")}
+		result.Status = "Pass"
+		if !pass || missing {
+			result.Status = "Fail"
+		}
+		result.Remarks = "Evaluated from telemetry log"
+		if missing {
+			result.Remarks = result.Remarks + ", some TM not logged"
+		}
+		if unattributed {
+			result.Remarks = result.Remarks + ", TM out of limits in the log without step attribution"
+		}
+		evaluation.Steps = append(evaluation.Steps, result)
+	}
+	return evaluation, nil
+}
+
+// withinLimits reports whether a sample lies within the limits of the
+// parameter, or is one of its status values.
+func withinLimits(value string, tm database.TelemetryParameter) bool {
+	if len(tm.StatusWords) > 0 {
+		for _, word := range tm.StatusWords {
+			if strings.EqualFold(word.Value, value) || strings.EqualFold(word.Status, value) {
+				return true
+			}
+		}
+		return false
+	}
+	lower, hasLower, _ := ParseLimit(tm.LowerLimit)
+	upper, hasUpper, _ := ParseLimit(tm.UpperLimit)
+	if !hasLower && !hasUpper {
+		return true
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	if hasLower && number < lower {
+		return false
+	}
+	if hasUpper && number > upper {
+		return false
+	}
+	return true
+}
+
+// matchesExpected compares an observed value with the expected value of a
+// step. Status values are compared by name, numbers within the tolerance,
+// which is absolute ("0.5", "±0.5") or relative to the expected value ("5%").
+func matchesExpected(observed string, expected string, tolerance string, tm database.TelemetryParameter) bool {
+	expected = strings.TrimSpace(expected)
+	for _, word := range tm.StatusWords {
+		if strings.EqualFold(word.Value, observed) {
+			observed = word.Status
+			break
+		}
+	}
+	if strings.EqualFold(observed, expected) {
+		return true
+	}
+	want, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+	got, err := strconv.ParseFloat(observed, 64)
+	if err != nil {
+		return false
+	}
+	allowed := 0.0
+	tolerance = strings.TrimSpace(tolerance)
+	tolerance = strings.TrimPrefix(tolerance, "±")
+	tolerance = strings.TrimPrefix(tolerance, "+/-")
+	tolerance = strings.TrimSpace(tolerance)
+	if strings.HasSuffix(tolerance, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(tolerance, "%")), 64)
+		if err == nil {
+			allowed = math.Abs(want) * percent / 100
+		}
+	} else if tolerance != "" {
+		value, err := strconv.ParseFloat(tolerance, 64)
+		if err == nil {
+			allowed = math.Abs(value)
+		}
+	}
+	return math.Abs(got-want) <= allowed+1e-9
+}
+
+// ApplyEvaluation updates the recorded result of a procedure with the steps
+// evaluated from a log. Anomaly references of earlier results are kept.
+func ApplyEvaluation(result database.ProcedureResult, evaluation LogEvaluation) database.ProcedureResult {
+	for _, evaluated := range evaluation.Steps {
+		replaced := false
+		for i := range result.Steps {
+			if result.Steps[i].Step == evaluated.Step {
+				result.Steps[i].Observed = evaluated.Observed
+				result.Steps[i].Status = evaluated.Status
+				result.Steps[i].Remarks = evaluated.Remarks
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result.Steps = append(result.Steps, evaluated)
+		}
+	}
+	result.OutOfLimits = evaluation.OutOfLimits
+	return result
+}
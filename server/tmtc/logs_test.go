@@ -0,0 +1,245 @@
+package tmtc
+
+import (
+	"encoding/json"
+	"intDocument/server/database"
+	"reflect"
+	"testing"
+)
+
+var testParameters = []database.TelemetryParameter{
+	{Mnemonic: "BUS_V", Channel: "A01", LowerLimit: "26", UpperLimit: "33"},
+	{Mnemonic: "BATT_T", Channel: "A02", LowerLimit: "-5", UpperLimit: "40"},
+	{Mnemonic: "PL_STS", Channel: "D01", StatusWords: []database.StatusWord{{Value: "0", Status: "OFF"}, {Value: "1", Status: "ON"}}},
+}
+
+var testTelecommands = []database.Telecommand{
+	{Mnemonic: "PL_ON", Code: "0x10", Description: "Payload on"},
+	{Mnemonic: "PL_OFF", Code: "0x11", Description: "Payload off"},
+}
+
+var testProcedure = database.Procedure{
+	Name: "PL-01",
+	Steps: []database.ProcedureStep{
+		{Action: "Switch payload on", Telecommand: "PL_ON", Telemetry: "PL_STS", ExpectedValue: "ON"},
+		{Action: "Check bus voltage", Telemetry: "BUS_V", ExpectedValue: "28", Tolerance: "±0.5"},
+		{Action: "Check battery", Telemetry: "BATT_T", ExpectedValue: "20", Tolerance: "10%"},
+		{Action: "Wait", WaitTime: "10 s"},
+	},
+}
+
+func TestMatchesExpected(t *testing.T) {
+	status := testParameters[2]
+	tests := []struct {
+		name      string
+		observed  string
+		expected  string
+		tolerance string
+		tm        database.TelemetryParameter
+		want      bool
+	}{
+		{"exact number", "28", "28", "", database.TelemetryParameter{}, true},
+		{"number outside zero tolerance", "28.1", "28", "", database.TelemetryParameter{}, false},
+		{"absolute tolerance", "28.4", "28", "0.5", database.TelemetryParameter{}, true},
+		{"plus minus tolerance", "27.5", "28", "±0.5", database.TelemetryParameter{}, true},
+		{"ascii plus minus tolerance", "28.6", "28", "+/-0.5", database.TelemetryParameter{}, false},
+		{"relative tolerance", "21.9", "20", "10%", database.TelemetryParameter{}, true},
+		{"relative tolerance exceeded", "22.1", "20", "10%", database.TelemetryParameter{}, false},
+		{"negative expected value", "-10.5", "-10", "5%", database.TelemetryParameter{}, true},
+		{"status word by value", "1", "ON", "", status, true},
+		{"status word mismatch", "0", "ON", "", status, false},
+		{"text ignores case", "on", "ON", "", database.TelemetryParameter{}, true},
+		{"text against number", "ON", "28", "1", database.TelemetryParameter{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := matchesExpected(test.observed, test.expected, test.tolerance, test.tm)
+			if got != test.want {
+				t.Errorf("matchesExpected(%q, %q, %q) = %v, want %v", test.observed, test.expected, test.tolerance, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		log         string
+		steps       []database.StepResult
+		outOfLimits []database.OutOfLimitSample
+	}{
+		{
+			name: "steps pass",
+			log: "Time,Step,PL_STS,BUS_V,BATT_T\n" +
+				"10:00:00,1,1,28.1,20\n" +
+				"10:00:01,2,1,28.2,20\n" +
+				"10:00:02,3,1,28.0,21\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "1", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "28.2", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 3, Observed: "21", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{},
+		},
+		{
+			name: "out of limits fails only its step",
+			log: "Time,Step,PL_STS,BUS_V,BATT_T\n" +
+				"10:00:00,1,1,28.1,20\n" +
+				"10:00:01,2,1,34,20\n" +
+				"10:00:02,2,1,28.2,20\n" +
+				"10:00:03,3,1,28.0,21\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "1", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "28.2", Status: "Fail", Remarks: "Evaluated from telemetry log"},
+				{Step: 3, Observed: "21", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{
+				{Time: "10:00:01", Step: 2, Mnemonic: "BUS_V", Value: "34", LowerLimit: "26", UpperLimit: "33"},
+			},
+		},
+		{
+			name: "out of limits without step column is unattributed",
+			log: "Time,PL_STS,BUS_V,BATT_T\n" +
+				"10:00:00,1,34,20\n" +
+				"10:00:01,1,28.2,20\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "1", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "28.2", Status: "Pass", Remarks: "Evaluated from telemetry log, TM out of limits in the log without step attribution"},
+				{Step: 3, Observed: "20", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{
+				{Time: "10:00:00", Step: 0, Mnemonic: "BUS_V", Value: "34", LowerLimit: "26", UpperLimit: "33"},
+			},
+		},
+		{
+			name: "out of limits in one row in column order",
+			log: "Time,Step,BATT_T,BUS_V,PL_STS\n" +
+				"10:00:00,1,20,28,1\n" +
+				"10:00:01,2,50,34,1\n" +
+				"10:00:02,2,20,28,1\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "1", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "28", Status: "Fail", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{
+				{Time: "10:00:01", Step: 2, Mnemonic: "BATT_T", Value: "50", LowerLimit: "-5", UpperLimit: "40"},
+				{Time: "10:00:01", Step: 2, Mnemonic: "BUS_V", Value: "34", LowerLimit: "26", UpperLimit: "33"},
+			},
+		},
+		{
+			name: "out of limits with an unreadable step is unattributed",
+			log: "Time,Step,PL_STS,BUS_V,BATT_T\n" +
+				"10:00:00,1,1,28.1,20\n" +
+				"10:00:01,2a,1,34,20\n" +
+				"10:00:02,2,1,28.2,20\n" +
+				"10:00:03,3,1,28.0,21\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "1", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "28.2", Status: "Pass", Remarks: "Evaluated from telemetry log, TM out of limits in the log without step attribution"},
+				{Step: 3, Observed: "21", Status: "Pass", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{
+				{Time: "10:00:01", Step: 0, Mnemonic: "BUS_V", Value: "34", LowerLimit: "26", UpperLimit: "33"},
+			},
+		},
+		{
+			name: "missing TM and wrong value",
+			log: "Time,Step,PL_STS,BUS_V\n" +
+				"10:00:00,1,0,28.1\n" +
+				"10:00:01,2,0,29\n",
+			steps: []database.StepResult{
+				{Step: 1, Observed: "0", Status: "Fail", Remarks: "Evaluated from telemetry log"},
+				{Step: 2, Observed: "29", Status: "Fail", Remarks: "Evaluated from telemetry log"},
+			},
+			outOfLimits: []database.OutOfLimitSample{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evaluation, err := EvaluateLog([]byte(test.log), testProcedure, testParameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(evaluation.Steps, test.steps) {
+				t.Errorf("steps = %+v, want %+v", evaluation.Steps, test.steps)
+			}
+			if !reflect.DeepEqual(evaluation.OutOfLimits, test.outOfLimits) {
+				t.Errorf("out of limits = %+v, want %+v", evaluation.OutOfLimits, test.outOfLimits)
+			}
+		})
+	}
+}
+
+func TestEvaluateLogErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+	}{
+		{"no samples", "Time,BUS_V\n"},
+		{"no time column", "Step,BUS_V\n1,28\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := EvaluateLog([]byte(test.log), testProcedure, testParameters)
+			if err == nil {
+				t.Errorf("EvaluateLog accepted a log with %s", test.name)
+			}
+		})
+	}
+}
+
+func TestSplitMnemonics(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", []string{}},
+		{"BUS_V", []string{"BUS_V"}},
+		{"BUS_V, BATT_T", []string{"BUS_V", "BATT_T"}},
+		{" ,BUS_V;;BATT_T ", []string{"BUS_V", "BATT_T"}},
+		{"\tBUS_V\nBATT_T", []string{"BUS_V", "BATT_T"}},
+	}
+	for _, test := range tests {
+		got := SplitMnemonics(test.text)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("SplitMnemonics(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestCheckMnemonics(t *testing.T) {
+	procedure, err := json.Marshal(database.Procedure{
+		Steps: []database.ProcedureStep{
+			{Action: "Switch on", Telecommand: "PL_ON", Telemetry: "PL_STS"},
+			{Action: "Read BUS_V and TEMP_99", Telemetry: "BATT_TT, HEATER_I"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	procedures := database.Content{
+		NoOfItems:   2,
+		ContentType: []string{"procedure", "text"},
+		FileName:    []string{"PL-01", ""},
+		Value:       []string{string(procedure), "Notes mention PL_OF and Payload"},
+		Captions:    []string{"", "Notes"},
+		Landscape:   []bool{false, false},
+	}
+	report := CheckMnemonics(procedures, testTelecommands, testParameters)
+
+	want := MnemonicReport{
+		Undefined: []MnemonicReference{
+			{Mnemonic: "HEATER_I", Item: "PL-01"},
+			{Mnemonic: "TEMP_99", Item: "PL-01"},
+		},
+		Misspelled: []MnemonicReference{
+			{Mnemonic: "BATT_TT", Item: "PL-01", Suggestion: "BATT_T"},
+			{Mnemonic: "PL_OF", Item: "Notes", Suggestion: "PL_OFF"},
+		},
+		UnusedTelecommands: []string{"PL_OFF"},
+		UnusedTelemetry:    []string{"BATT_T"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+}
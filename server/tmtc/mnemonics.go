@@ -0,0 +1,253 @@
+package tmtc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"intDocument/server/database"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type MnemonicReference struct {
+	Mnemonic   string
+	Item       string
+	Suggestion string
+}
+
+type MnemonicReport struct {
+	Undefined          []MnemonicReference
+	Misspelled         []MnemonicReference
+	UnusedTelecommands []string
+	UnusedTelemetry    []string
+}
+
+var wordPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9_]*`)
+var listSeparator = regexp.MustCompile(`[,;\s]+`)
+
+// SplitMnemonics returns the mnemonics of a comma, semicolon or space
+// separated list, such as the TC or TM of a procedure step, without the
+// empty entries left by leading or doubled separators.
+func SplitMnemonics(text string) []string {
+	names := make([]string, 0)
+	for _, name := range listSeparator.Split(text, -1) {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CheckDocument compares the mnemonics referenced in the TestProcedures of
+// the document with its telecommand and telemetry lists, for a user who may
+// view the document.
+func CheckDocument(documentName string, user database.User) (string, MnemonicReport, bool) {
+	var report MnemonicReport
+	errMsg, procedures, ok := database.GetContent(documentName, "TestProcedures", user)
+	if !ok {
+		return errMsg, report, false
+	}
+	errMsg, tcList, ok := database.GetTelecommands(documentName, user)
+	if !ok {
+		return errMsg, report, false
+	}
+	errMsg, tmList, ok := database.GetTelemetry(documentName, user)
+	if !ok {
+		return errMsg, report, false
+	}
+	return "", CheckMnemonics(procedures, tcList.Telecommands, tmList.Parameters), true
+}
+
+// CheckMnemonics reports the mnemonics of the procedure content that are not
+// defined, those that look like a misspelling of a defined one, and the
+// telecommands and telemetry parameters that no procedure uses.
+//
+// Procedure items name their TC and TM explicitly, so every entry there is a
+// reference. In text, code, tables and excel files a word is taken as a
+// mnemonic if it is defined, or if it is upper case and contains a digit or
+// an underscore, like BATT_V or TC101.
+func CheckMnemonics(procedures database.Content, telecommands []database.Telecommand, parameters []database.TelemetryParameter) MnemonicReport {
+	var report MnemonicReport
+	report.Undefined = make([]MnemonicReference, 0)
+	report.Misspelled = make([]MnemonicReference, 0)
+	report.UnusedTelecommands = make([]string, 0)
+	report.UnusedTelemetry = make([]string, 0)
+
+	known := make(map[string]string)
+	for _, tc := range telecommands {
+		known[strings.ToUpper(tc.Mnemonic)] = tc.Mnemonic
+	}
+	for _, tm := range parameters {
+		known[strings.ToUpper(tm.Mnemonic)] = tm.Mnemonic
+	}
+	knownNames := make([]string, 0)
+	for name := range known {
+		knownNames = append(knownNames, name)
+	}
+	sort.Strings(knownNames)
+
+	used := make(map[string]bool)
+	reported := make(map[string]bool)
+	check := func(word string, item string, explicit bool) {
+		upper := strings.ToUpper(word)
+		if _, ok := known[upper]; ok {
+			used[upper] = true
+			return
+		}
+		isUpper := word == upper
+		if !explicit && !isUpper {
+			return
+		}
+		key := upper + "\x00" + item
+		if reported[key] {
+			return
+		}
+		suggestion := getClosest(upper, knownNames)
+		if suggestion != "" && (explicit || len(word) >= 4) {
+			reported[key] = true
+			report.Misspelled = append(report.Misspelled, MnemonicReference{Mnemonic: word, Item: item, Suggestion: known[suggestion]})
+			return
+		}
+		if explicit || isMnemonicLike(word) {
+			reported[key] = true
+			report.Undefined = append(report.Undefined, MnemonicReference{Mnemonic: word, Item: item})
+		}
+	}
+
+	for i := 0; i < procedures.NoOfItems; i++ {
+		item := getItemName(procedures, i)
+		contentType := strings.ToLower(procedures.ContentType[i])
+		if contentType == "procedure" {
+			procedure, err := database.ParseProcedure(procedures.Value[i])
+			if err != nil {
+				continue
+			}
+			for _, step := range procedure.Steps {
+				for _, word := range SplitMnemonics(step.Telecommand + " " + step.Telemetry) {
+					check(word, item, true)
+				}
+				for _, word := range wordPattern.FindAllString(step.Action+" "+step.Remarks, -1) {
+					check(word, item, false)
+				}
+			}
+			continue
+		}
+		for _, word := range wordPattern.FindAllString(getItemText(contentType, procedures.Value[i]), -1) {
+			check(word, item, false)
+		}
+	}
+
+	for _, tc := range telecommands {
+		if !used[strings.ToUpper(tc.Mnemonic)] {
+			report.UnusedTelecommands = append(report.UnusedTelecommands, tc.Mnemonic)
+		}
+	}
+	for _, tm := range parameters {
+		if !used[strings.ToUpper(tm.Mnemonic)] {
+			report.UnusedTelemetry = append(report.UnusedTelemetry, tm.Mnemonic)
+		}
+	}
+	return report
+}
+
+func getItemName(content database.Content, i int) string {
+	if strings.TrimSpace(content.FileName[i]) != "" {
+		return content.FileName[i]
+	}
+	if strings.TrimSpace(content.Captions[i]) != "" {
+		return content.Captions[i]
+	}
+	return "Item " + strconv.Itoa(i+1)
+}
+
+// getItemText returns the searchable text of a content item. Images and PDF
+// files have none.
+func getItemText(contentType string, value string) string {
+	switch contentType {
+	case "text", "code", "table":
+		return value
+	case "richtext":
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return ""
+		}
+		var deltas []struct {
+			Insert string `json:"insert"`
+		}
+		json.Unmarshal(data, &deltas)
+		text := ""
+		for _, delta := range deltas {
+			text = text + delta.Insert
+		}
+		return text
+	case "excel":
+		data, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return ""
+		}
+		rows, err := ReadTable(data, "xlsx")
+		if err != nil {
+			return ""
+		}
+		text := ""
+		for _, row := range rows {
+			text = text + strings.Join(row, " ") + "\n"
+		}
+		return text
+	default:
+		return ""
+	}
+}
+
+func isMnemonicLike(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+	for _, r := range word {
+		if r == '_' || unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// getClosest returns the known mnemonic nearest to word if it is close enough
+// to be a misspelling, otherwise an empty string.
+func getClosest(word string, knownNames []string) string {
+	allowed := 1
+	if len(word) > 5 {
+		allowed = 2
+	}
+	closest := ""
+	best := allowed + 1
+	for _, name := range knownNames {
+		distance := editDistance(word, name)
+		if distance < best {
+			best = distance
+			closest = name
+		}
+	}
+	return closest
+}
+
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,94 @@
+package tmtc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/thedatashed/xlsxreader"
+)
+
+// ReadTable reads the rows of a CSV file or of the first sheet of an XLSX
+// file. The format is "csv" or "xlsx".
+func ReadTable(data []byte, format string) ([][]string, error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case "xlsx":
+		xl, err := xlsxreader.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read excel file: %w", err)
+		}
+		if len(xl.Sheets) == 0 {
+			return nil, fmt.Errorf("excel file has no sheets")
+		}
+		rows := make([][]string, 0)
+		for row := range xl.ReadRows(xl.Sheets[0]) {
+			if row.Error != nil {
+				return nil, row.Error
+			}
+			values := make([]string, 0)
+			for _, cell := range row.Cells {
+				// Cells may be missing for empty columns, so place each one
+				// by its column index.
+				index := cell.ColumnIndex()
+				for len(values) <= index {
+					values = append(values, "")
+				}
+				values[index] = cell.Value
+			}
+			rows = append(rows, values)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unknown format %s, expected csv or xlsx", format)
+	}
+}
+
+// normaliseHeader makes column names comparable by ignoring case, spaces,
+// underscores and dashes.
+func normaliseHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, " ", "")
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, "-", "")
+	return name
+}
+
+// getColumns maps each field name to its column index in the header row.
+// aliases lists the accepted header names for each field.
+func getColumns(header []string, aliases map[string][]string) map[string]int {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = normaliseHeader(name)
+		for field, names := range aliases {
+			for _, alias := range names {
+				if name == alias {
+					columns[field] = i
+				}
+			}
+		}
+	}
+	return columns
+}
+
+func getCell(row []string, columns map[string]int, field string) string {
+	index, ok := columns[field]
+	if !ok || index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func isEmptyRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
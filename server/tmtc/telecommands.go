@@ -0,0 +1,108 @@
+package tmtc
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"strings"
+)
+
+var telecommandColumns = map[string][]string{
+	"Mnemonic":       {"mnemonic", "tcmnemonic", "tc"},
+	"Code":           {"code", "tccode", "commandcode"},
+	"Description":    {"description"},
+	"Type":           {"type", "tctype"},
+	"VerificationTM": {"verificationtm", "verification", "verifytm"},
+	"Criticality":    {"criticality", "critical"},
+}
+
+// ImportTelecommands reads a telecommand list from a CSV or XLSX file whose
+// first row names the columns.
+func ImportTelecommands(data []byte, format string) ([]database.Telecommand, error) {
+	rows, err := ReadTable(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	columns := getColumns(rows[0], telecommandColumns)
+	if _, ok := columns["Mnemonic"]; !ok {
+		return nil, fmt.Errorf("mnemonic column not found in the header row")
+	}
+	telecommands := make([]database.Telecommand, 0)
+	for _, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		var tc database.Telecommand
+		tc.Mnemonic = getCell(row, columns, "Mnemonic")
+		tc.Code = getCell(row, columns, "Code")
+		tc.Description = getCell(row, columns, "Description")
+		tc.Type = getCell(row, columns, "Type")
+		tc.VerificationTM = getCell(row, columns, "VerificationTM")
+		tc.Criticality = getCell(row, columns, "Criticality")
+		telecommands = append(telecommands, tc)
+	}
+	return telecommands, nil
+}
+
+// MergeTelecommands adds the imported telecommands to the existing list. An
+// imported telecommand replaces the existing one with the same mnemonic.
+func MergeTelecommands(existing []database.Telecommand, imported []database.Telecommand) []database.Telecommand {
+	merged := make([]database.Telecommand, 0)
+	merged = append(merged, existing...)
+	for _, tc := range imported {
+		replaced := false
+		for i := range merged {
+			if strings.EqualFold(merged[i].Mnemonic, tc.Mnemonic) {
+				merged[i] = tc
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, tc)
+		}
+	}
+	return merged
+}
+
+// ValidateTelecommands returns the problems found in the list: missing
+// mandatory fields and duplicate mnemonics or codes.
+func ValidateTelecommands(telecommands []database.Telecommand) []string {
+	problems := make([]string, 0)
+	mnemonics := make(map[string]int)
+	codes := make(map[string]int)
+	for i, tc := range telecommands {
+		row := fmt.Sprintf("Row %d", i+1)
+		if tc.Mnemonic != "" {
+			row = row + " (" + tc.Mnemonic + ")"
+		}
+		if strings.TrimSpace(tc.Mnemonic) == "" {
+			problems = append(problems, row+": Mnemonic is missing")
+		}
+		if strings.TrimSpace(tc.Code) == "" {
+			problems = append(problems, row+": Code is missing")
+		}
+		if strings.TrimSpace(tc.Description) == "" {
+			problems = append(problems, row+": Description is missing")
+		}
+		mnemonic := strings.ToUpper(strings.TrimSpace(tc.Mnemonic))
+		if mnemonic != "" {
+			if first, ok := mnemonics[mnemonic]; ok {
+				problems = append(problems, fmt.Sprintf("%s: Duplicate Mnemonic, first used in row %d", row, first))
+			} else {
+				mnemonics[mnemonic] = i + 1
+			}
+		}
+		code := strings.ToUpper(strings.TrimSpace(tc.Code))
+		if code != "" {
+			if first, ok := codes[code]; ok {
+				problems = append(problems, fmt.Sprintf("%s: Duplicate Code, first used in row %d", row, first))
+			} else {
+				codes[code] = i + 1
+			}
+		}
+	}
+	return problems
+}
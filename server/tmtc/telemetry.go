@@ -0,0 +1,186 @@
+package tmtc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"intDocument/server/database"
+	"strconv"
+	"strings"
+)
+
+var telemetryColumns = map[string][]string{
+	"Mnemonic":    {"mnemonic", "tmmnemonic", "tm", "parameter"},
+	"Channel":     {"channel", "channelno", "pid"},
+	"Units":       {"units", "unit"},
+	"Calibration": {"calibration", "cal"},
+	"LowerLimit":  {"lowerlimit", "lower", "min", "low"},
+	"UpperLimit":  {"upperlimit", "upper", "max", "high"},
+	"StatusWords": {"status", "statuswords", "states"},
+}
+
+// ParseStatusWords reads status words written as "0=OFF;1=ON".
+func ParseStatusWords(text string) []database.StatusWord {
+	words := make([]database.StatusWord, 0)
+	for _, pair := range strings.Split(text, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		var word database.StatusWord
+		parts := strings.SplitN(pair, "=", 2)
+		word.Value = strings.TrimSpace(parts[0])
+		if len(parts) == 2 {
+			word.Status = strings.TrimSpace(parts[1])
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// FormatStatusWords writes status words as "0=OFF;1=ON".
+func FormatStatusWords(words []database.StatusWord) string {
+	pairs := make([]string, 0)
+	for _, word := range words {
+		pairs = append(pairs, word.Value+"="+word.Status)
+	}
+	return strings.Join(pairs, ";")
+}
+
+// ImportTelemetry reads telemetry parameters from a CSV or XLSX file whose
+// first row names the columns.
+func ImportTelemetry(data []byte, format string) ([]database.TelemetryParameter, error) {
+	rows, err := ReadTable(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	columns := getColumns(rows[0], telemetryColumns)
+	if _, ok := columns["Mnemonic"]; !ok {
+		return nil, fmt.Errorf("mnemonic column not found in the header row")
+	}
+	parameters := make([]database.TelemetryParameter, 0)
+	for _, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		var tm database.TelemetryParameter
+		tm.Mnemonic = getCell(row, columns, "Mnemonic")
+		tm.Channel = getCell(row, columns, "Channel")
+		tm.Units = getCell(row, columns, "Units")
+		tm.Calibration = getCell(row, columns, "Calibration")
+		tm.LowerLimit = getCell(row, columns, "LowerLimit")
+		tm.UpperLimit = getCell(row, columns, "UpperLimit")
+		tm.StatusWords = ParseStatusWords(getCell(row, columns, "StatusWords"))
+		parameters = append(parameters, tm)
+	}
+	return parameters, nil
+}
+
+// ExportTelemetry writes the parameters as CSV in the layout accepted by
+// ImportTelemetry.
+func ExportTelemetry(parameters []database.TelemetryParameter) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write([]string{"Mnemonic", "Channel", "Units", "Calibration", "Lower Limit", "Upper Limit", "Status"})
+	for _, tm := range parameters {
+		writer.Write([]string{tm.Mnemonic, tm.Channel, tm.Units, tm.Calibration, tm.LowerLimit, tm.UpperLimit, FormatStatusWords(tm.StatusWords)})
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// MergeTelemetry adds the imported parameters to the existing list. An
+// imported parameter replaces the existing one with the same mnemonic.
+func MergeTelemetry(existing []database.TelemetryParameter, imported []database.TelemetryParameter) []database.TelemetryParameter {
+	merged := make([]database.TelemetryParameter, 0)
+	merged = append(merged, existing...)
+	for _, tm := range imported {
+		replaced := false
+		for i := range merged {
+			if strings.EqualFold(merged[i].Mnemonic, tm.Mnemonic) {
+				merged[i] = tm
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, tm)
+		}
+	}
+	return merged
+}
+
+// ParseLimit returns the numeric value of a limit and whether one is set.
+func ParseLimit(limit string) (float64, bool, error) {
+	limit = strings.TrimSpace(limit)
+	if limit == "" {
+		return 0, false, nil
+	}
+	value, err := strconv.ParseFloat(limit, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return value, true, nil
+}
+
+// ValidateTelemetry returns the problems found in the list: missing
+// mandatory fields, duplicate mnemonics or channels, limits which are not
+// numbers and duplicate status values.
+func ValidateTelemetry(parameters []database.TelemetryParameter) []string {
+	problems := make([]string, 0)
+	mnemonics := make(map[string]int)
+	channels := make(map[string]int)
+	for i, tm := range parameters {
+		row := fmt.Sprintf("Row %d", i+1)
+		if tm.Mnemonic != "" {
+			row = row + " (" + tm.Mnemonic + ")"
+		}
+		if strings.TrimSpace(tm.Mnemonic) == "" {
+			problems = append(problems, row+": Mnemonic is missing")
+		}
+		if strings.TrimSpace(tm.Channel) == "" {
+			problems = append(problems, row+": Channel is missing")
+		}
+		mnemonic := strings.ToUpper(strings.TrimSpace(tm.Mnemonic))
+		if mnemonic != "" {
+			if first, ok := mnemonics[mnemonic]; ok {
+				problems = append(problems, fmt.Sprintf("%s: Duplicate Mnemonic, first used in row %d", row, first))
+			} else {
+				mnemonics[mnemonic] = i + 1
+			}
+		}
+		channel := strings.ToUpper(strings.TrimSpace(tm.Channel))
+		if channel != "" {
+			if first, ok := channels[channel]; ok {
+				problems = append(problems, fmt.Sprintf("%s: Duplicate Channel, first used in row %d", row, first))
+			} else {
+				channels[channel] = i + 1
+			}
+		}
+		lower, hasLower, err := ParseLimit(tm.LowerLimit)
+		if err != nil {
+			problems = append(problems, row+": Lower Limit is not a number")
+		}
+		upper, hasUpper, err := ParseLimit(tm.UpperLimit)
+		if err != nil {
+			problems = append(problems, row+": Upper Limit is not a number")
+		}
+		if hasLower && hasUpper && lower > upper {
+			problems = append(problems, row+": Lower Limit is greater than Upper Limit")
+		}
+		values := make(map[string]bool)
+		for _, word := range tm.StatusWords {
+			if strings.TrimSpace(word.Value) == "" || strings.TrimSpace(word.Status) == "" {
+				problems = append(problems, row+": Status word needs both value and status")
+				continue
+			}
+			if values[word.Value] {
+				problems = append(problems, row+": Duplicate status value "+word.Value)
+			}
+			values[word.Value] = true
+		}
+	}
+	return problems
+}
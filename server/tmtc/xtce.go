@@ -0,0 +1,374 @@
+package tmtc
+
+import (
+	"encoding/xml"
+	"fmt"
+	"intDocument/server/database"
+	"strings"
+)
+
+const xtceNamespace = "http://www.omg.org/spec/XTCE/20180204"
+
+// The XTCE elements read and written by the importer and exporter. Fields of
+// the IST document that XTCE has no place for are kept as AncillaryData.
+
+type xtceSpaceSystem struct {
+	XMLName      xml.Name               `xml:"SpaceSystem"`
+	Xmlns        string                 `xml:"xmlns,attr,omitempty"`
+	Name         string                 `xml:"name,attr"`
+	Telemetry    *xtceTelemetryMetaData `xml:"TelemetryMetaData"`
+	Command      *xtceCommandMetaData   `xml:"CommandMetaData"`
+	SpaceSystems []xtceSpaceSystem      `xml:"SpaceSystem"`
+}
+
+type xtceTelemetryMetaData struct {
+	ParameterTypes xtceParameterTypeSet `xml:"ParameterTypeSet"`
+	Parameters     []xtceParameter      `xml:"ParameterSet>Parameter"`
+}
+
+type xtceParameterTypeSet struct {
+	Float      []xtceNumericType    `xml:"FloatParameterType"`
+	Integer    []xtceNumericType    `xml:"IntegerParameterType"`
+	Enumerated []xtceEnumeratedType `xml:"EnumeratedParameterType"`
+}
+
+type xtceNumericType struct {
+	Name  string     `xml:"name,attr"`
+	Units []string   `xml:"UnitSet>Unit"`
+	Alarm *xtceAlarm `xml:"DefaultAlarm"`
+}
+
+type xtceAlarm struct {
+	Warning  *xtceRange `xml:"StaticAlarmRanges>WarningRange"`
+	Critical *xtceRange `xml:"StaticAlarmRanges>CriticalRange"`
+}
+
+type xtceRange struct {
+	MinInclusive string `xml:"minInclusive,attr,omitempty"`
+	MaxInclusive string `xml:"maxInclusive,attr,omitempty"`
+}
+
+type xtceEnumeratedType struct {
+	Name         string            `xml:"name,attr"`
+	Units        []string          `xml:"UnitSet>Unit"`
+	Enumerations []xtceEnumeration `xml:"EnumerationList>Enumeration"`
+}
+
+type xtceEnumeration struct {
+	Value string `xml:"value,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type xtceParameter struct {
+	Name             string          `xml:"name,attr"`
+	TypeRef          string          `xml:"parameterTypeRef,attr"`
+	ShortDescription string          `xml:"shortDescription,attr,omitempty"`
+	Aliases          []xtceAlias     `xml:"AliasSet>Alias"`
+	Ancillary        []xtceAncillary `xml:"AncillaryDataSet>AncillaryData"`
+}
+
+type xtceAlias struct {
+	NameSpace string `xml:"nameSpace,attr"`
+	Alias     string `xml:"alias,attr"`
+}
+
+type xtceAncillary struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xtceCommandMetaData struct {
+	Commands []xtceMetaCommand `xml:"MetaCommandSet>MetaCommand"`
+}
+
+type xtceMetaCommand struct {
+	Name             string                `xml:"name,attr"`
+	Abstract         bool                  `xml:"abstract,attr,omitempty"`
+	ShortDescription string                `xml:"shortDescription,attr,omitempty"`
+	LongDescription  string                `xml:"LongDescription,omitempty"`
+	Aliases          []xtceAlias           `xml:"AliasSet>Alias"`
+	Ancillary        []xtceAncillary       `xml:"AncillaryDataSet>AncillaryData"`
+	Container        *xtceCommandContainer `xml:"CommandContainer"`
+	Significance     *xtceSignificance     `xml:"DefaultSignificance"`
+}
+
+type xtceCommandContainer struct {
+	Name  string           `xml:"name,attr"`
+	Fixed []xtceFixedValue `xml:"EntryList>FixedValueEntry"`
+}
+
+type xtceFixedValue struct {
+	BinaryValue string `xml:"binaryValue,attr"`
+}
+
+type xtceSignificance struct {
+	ConsequenceLevel string `xml:"consequenceLevel,attr"`
+}
+
+func getAncillary(data []xtceAncillary, name string) string {
+	for _, item := range data {
+		if strings.EqualFold(item.Name, name) {
+			return strings.TrimSpace(item.Value)
+		}
+	}
+	return ""
+}
+
+// The alias name spaces, compared without case, that other tools use for
+// the channel of a parameter and the code of a command.
+var channelNameSpaces = []string{"Channel", "MDB:OPS Name"}
+var codeNameSpaces = []string{"Code", "Opcode", "OpCode"}
+
+// getAlias returns the first alias in one of the name spaces. Aliases in
+// other name spaces mean something else and are ignored.
+func getAlias(aliases []xtceAlias, nameSpaces []string) string {
+	for _, alias := range aliases {
+		if strings.TrimSpace(alias.Alias) == "" {
+			continue
+		}
+		for _, nameSpace := range nameSpaces {
+			if strings.EqualFold(strings.TrimSpace(alias.NameSpace), nameSpace) {
+				return strings.TrimSpace(alias.Alias)
+			}
+		}
+	}
+	return ""
+}
+
+// The ways a field of the IST document is filled in when the XTCE file has no
+// AncillaryData for it, in the order they are reported, with the warning
+// that says how often it happened.
+var importNotes = []struct {
+	key     string
+	warning string
+}{
+	{"ChannelAlias", "%d telemetry parameters take their channel from an alias"},
+	{"Channel", "%d telemetry parameters have no channel"},
+	{"CodeContainer", "%d telecommands take their code from the fixed value of their command container"},
+	{"CodeAlias", "%d telecommands take their code from an alias"},
+	{"Code", "%d telecommands have no code"},
+	{"DescriptionLong", "%d telecommands take their description from the long description"},
+	{"Description", "%d telecommands have no description"},
+}
+
+func addAncillary(data []xtceAncillary, name string, value string) []xtceAncillary {
+	if value == "" {
+		return data
+	}
+	return append(data, xtceAncillary{Name: name, Value: value})
+}
+
+// typeName strips the path of a parameterTypeRef such as /SAT/EPS/VoltType.
+func typeName(ref string) string {
+	index := strings.LastIndex(ref, "/")
+	return ref[index+1:]
+}
+
+// ImportXTCE reads the telecommands and telemetry parameters of an XTCE file,
+// including those of nested space systems. Abstract commands are skipped.
+// XTCE has no place for the channel of a parameter or the code and
+// description of a command, so files not written by ExportXTCE usually lack
+// them. They are then taken from an alias in a known name space, the fixed
+// value of the command container or the long description, and otherwise
+// left empty for the validation to report. The returned warnings say how
+// often each of these happened.
+func ImportXTCE(data []byte) ([]database.Telecommand, []database.TelemetryParameter, []string, error) {
+	var root xtceSpaceSystem
+	err := xml.Unmarshal(data, &root)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot read XTCE file: %w", err)
+	}
+
+	systems := make([]xtceSpaceSystem, 0)
+	pending := []xtceSpaceSystem{root}
+	for len(pending) > 0 {
+		system := pending[0]
+		pending = pending[1:]
+		systems = append(systems, system)
+		pending = append(pending, system.SpaceSystems...)
+	}
+
+	// Parameter types may be defined in any space system.
+	numericTypes := make(map[string]xtceNumericType)
+	enumeratedTypes := make(map[string]xtceEnumeratedType)
+	for _, system := range systems {
+		if system.Telemetry == nil {
+			continue
+		}
+		for _, t := range system.Telemetry.ParameterTypes.Float {
+			numericTypes[t.Name] = t
+		}
+		for _, t := range system.Telemetry.ParameterTypes.Integer {
+			numericTypes[t.Name] = t
+		}
+		for _, t := range system.Telemetry.ParameterTypes.Enumerated {
+			enumeratedTypes[t.Name] = t
+		}
+	}
+
+	telecommands := make([]database.Telecommand, 0)
+	parameters := make([]database.TelemetryParameter, 0)
+	notes := make(map[string]int)
+	for _, system := range systems {
+		if system.Telemetry != nil {
+			for _, p := range system.Telemetry.Parameters {
+				parameters = append(parameters, getParameterFromXTCE(p, numericTypes, enumeratedTypes, notes))
+			}
+		}
+		if system.Command != nil {
+			for _, mc := range system.Command.Commands {
+				if mc.Abstract {
+					continue
+				}
+				telecommands = append(telecommands, getTelecommandFromXTCE(mc, notes))
+			}
+		}
+	}
+
+	warnings := make([]string, 0)
+	for _, note := range importNotes {
+		if notes[note.key] > 0 {
+			warnings = append(warnings, fmt.Sprintf(note.warning, notes[note.key]))
+		}
+	}
+	return telecommands, parameters, warnings, nil
+}
+
+func getParameterFromXTCE(p xtceParameter, numericTypes map[string]xtceNumericType, enumeratedTypes map[string]xtceEnumeratedType, notes map[string]int) database.TelemetryParameter {
+	var tm database.TelemetryParameter
+	tm.Mnemonic = p.Name
+	tm.Channel = getAncillary(p.Ancillary, "Channel")
+	if tm.Channel == "" {
+		tm.Channel = getAlias(p.Aliases, channelNameSpaces)
+		if tm.Channel != "" {
+			notes["ChannelAlias"]++
+		} else {
+			notes["Channel"]++
+		}
+	}
+	tm.Calibration = getAncillary(p.Ancillary, "Calibration")
+	tm.StatusWords = make([]database.StatusWord, 0)
+	ref := typeName(p.TypeRef)
+	if t, ok := numericTypes[ref]; ok {
+		if len(t.Units) > 0 {
+			tm.Units = t.Units[0]
+		}
+		if t.Alarm != nil {
+			limits := t.Alarm.Warning
+			if limits == nil {
+				limits = t.Alarm.Critical
+			}
+			if limits != nil {
+				tm.LowerLimit = limits.MinInclusive
+				tm.UpperLimit = limits.MaxInclusive
+			}
+		}
+	}
+	if t, ok := enumeratedTypes[ref]; ok {
+		if len(t.Units) > 0 {
+			tm.Units = t.Units[0]
+		}
+		for _, e := range t.Enumerations {
+			tm.StatusWords = append(tm.StatusWords, database.StatusWord{Value: e.Value, Status: e.Label})
+		}
+	}
+	return tm
+}
+
+func getTelecommandFromXTCE(mc xtceMetaCommand, notes map[string]int) database.Telecommand {
+	var tc database.Telecommand
+	tc.Mnemonic = mc.Name
+	tc.Description = mc.ShortDescription
+	if tc.Description == "" {
+		tc.Description = strings.TrimSpace(mc.LongDescription)
+		if tc.Description != "" {
+			notes["DescriptionLong"]++
+		} else {
+			notes["Description"]++
+		}
+	}
+	tc.Code = getAncillary(mc.Ancillary, "Code")
+	if tc.Code == "" && mc.Container != nil && len(mc.Container.Fixed) > 0 {
+		tc.Code = mc.Container.Fixed[0].BinaryValue
+		if tc.Code != "" {
+			notes["CodeContainer"]++
+		}
+	}
+	if tc.Code == "" {
+		tc.Code = getAlias(mc.Aliases, codeNameSpaces)
+		if tc.Code != "" {
+			notes["CodeAlias"]++
+		} else {
+			notes["Code"]++
+		}
+	}
+	tc.Type = getAncillary(mc.Ancillary, "Type")
+	tc.VerificationTM = getAncillary(mc.Ancillary, "VerificationTM")
+	tc.Criticality = getAncillary(mc.Ancillary, "Criticality")
+	if tc.Criticality == "" && mc.Significance != nil {
+		tc.Criticality = mc.Significance.ConsequenceLevel
+	}
+	return tc
+}
+
+// ExportXTCE writes the telecommands and telemetry parameters as an XTCE
+// space system with the given name.
+func ExportXTCE(name string, telecommands []database.Telecommand, parameters []database.TelemetryParameter) ([]byte, error) {
+	var root xtceSpaceSystem
+	root.Xmlns = xtceNamespace
+	root.Name = name
+
+	if len(parameters) > 0 {
+		root.Telemetry = &xtceTelemetryMetaData{}
+		for _, tm := range parameters {
+			var p xtceParameter
+			p.Name = tm.Mnemonic
+			p.TypeRef = tm.Mnemonic + "_Type"
+			p.Ancillary = addAncillary(p.Ancillary, "Channel", tm.Channel)
+			p.Ancillary = addAncillary(p.Ancillary, "Calibration", tm.Calibration)
+			root.Telemetry.Parameters = append(root.Telemetry.Parameters, p)
+
+			if len(tm.StatusWords) > 0 {
+				var t xtceEnumeratedType
+				t.Name = p.TypeRef
+				if tm.Units != "" {
+					t.Units = []string{tm.Units}
+				}
+				for _, word := range tm.StatusWords {
+					t.Enumerations = append(t.Enumerations, xtceEnumeration{Value: word.Value, Label: word.Status})
+				}
+				root.Telemetry.ParameterTypes.Enumerated = append(root.Telemetry.ParameterTypes.Enumerated, t)
+				continue
+			}
+			var t xtceNumericType
+			t.Name = p.TypeRef
+			if tm.Units != "" {
+				t.Units = []string{tm.Units}
+			}
+			if tm.LowerLimit != "" || tm.UpperLimit != "" {
+				t.Alarm = &xtceAlarm{Warning: &xtceRange{MinInclusive: tm.LowerLimit, MaxInclusive: tm.UpperLimit}}
+			}
+			root.Telemetry.ParameterTypes.Float = append(root.Telemetry.ParameterTypes.Float, t)
+		}
+	}
+
+	if len(telecommands) > 0 {
+		root.Command = &xtceCommandMetaData{}
+		for _, tc := range telecommands {
+			var mc xtceMetaCommand
+			mc.Name = tc.Mnemonic
+			mc.ShortDescription = tc.Description
+			mc.Ancillary = addAncillary(mc.Ancillary, "Code", tc.Code)
+			mc.Ancillary = addAncillary(mc.Ancillary, "Type", tc.Type)
+			mc.Ancillary = addAncillary(mc.Ancillary, "VerificationTM", tc.VerificationTM)
+			mc.Ancillary = addAncillary(mc.Ancillary, "Criticality", tc.Criticality)
+			root.Command.Commands = append(root.Command.Commands, mc)
+		}
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
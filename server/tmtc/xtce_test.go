@@ -0,0 +1,85 @@
+package tmtc
+
+import (
+	"intDocument/server/database"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestImportXTCEWithoutDocumentFields(t *testing.T) {
+	data, err := os.ReadFile("testdata/BogusSAT.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	telecommands, parameters, warnings, err := ImportXTCE(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantTC := []database.Telecommand{
+		{Mnemonic: "Reboot"},
+		{Mnemonic: "SetPayloadPower", Code: "2A", Description: "Switches the payload on or off"},
+	}
+	if !reflect.DeepEqual(telecommands, wantTC) {
+		t.Errorf("telecommands = %+v, want %+v", telecommands, wantTC)
+	}
+
+	wantTM := []database.TelemetryParameter{
+		{Mnemonic: "CCSDS_APID", StatusWords: []database.StatusWord{}},
+		{Mnemonic: "Battery_Voltage", Channel: "EPS_BV_01", Units: "V", LowerLimit: "26.0", UpperLimit: "33.0", StatusWords: []database.StatusWord{}},
+		{Mnemonic: "Payload_Power", StatusWords: []database.StatusWord{{Value: "0", Status: "OFF"}, {Value: "1", Status: "ON"}}},
+	}
+	if !reflect.DeepEqual(parameters, wantTM) {
+		t.Errorf("parameters = %+v, want %+v", parameters, wantTM)
+	}
+
+	wantWarnings := []string{
+		"1 telemetry parameters take their channel from an alias",
+		"2 telemetry parameters have no channel",
+		"1 telecommands take their code from the fixed value of their command container",
+		"1 telecommands have no code",
+		"1 telecommands take their description from the long description",
+		"1 telecommands have no description",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", warnings, wantWarnings)
+	}
+
+	// The fields that could not be filled in are left for the validation.
+	wantTCProblems := []string{"Row 1 (Reboot): Code is missing", "Row 1 (Reboot): Description is missing"}
+	if problems := ValidateTelecommands(telecommands); !reflect.DeepEqual(problems, wantTCProblems) {
+		t.Errorf("telecommand problems = %q, want %q", problems, wantTCProblems)
+	}
+	wantTMProblems := []string{"Row 1 (CCSDS_APID): Channel is missing", "Row 3 (Payload_Power): Channel is missing"}
+	if problems := ValidateTelemetry(parameters); !reflect.DeepEqual(problems, wantTMProblems) {
+		t.Errorf("telemetry problems = %q, want %q", problems, wantTMProblems)
+	}
+}
+
+func TestXTCERoundTrip(t *testing.T) {
+	telecommands := []database.Telecommand{
+		{Mnemonic: "PWR_ON", Code: "0x1A", Description: "Power on", Type: "Pulse", VerificationTM: "PWR_STS", Criticality: "High"},
+	}
+	parameters := []database.TelemetryParameter{
+		{Mnemonic: "BUS_V", Channel: "A01", Units: "V", Calibration: "0.1*x", LowerLimit: "26", UpperLimit: "33", StatusWords: []database.StatusWord{}},
+		{Mnemonic: "PWR_STS", Channel: "D01", StatusWords: []database.StatusWord{{Value: "0", Status: "OFF"}, {Value: "1", Status: "ON"}}},
+	}
+	data, err := ExportXTCE("SAT", telecommands, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotTC, gotTM, warnings, err := ImportXTCE(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotTC, telecommands) {
+		t.Errorf("telecommands = %+v, want %+v", gotTC, telecommands)
+	}
+	if !reflect.DeepEqual(gotTM, parameters) {
+		t.Errorf("parameters = %+v, want %+v", gotTM, parameters)
+	}
+	if len(warnings) > 0 {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
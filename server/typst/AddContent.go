@@ -9,7 +9,7 @@ import (
 func addContent(id string, cnt database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := "\n"
 	if cnt.NoOfItems == 0 {
-		content = content + "Not Applicable\n"
+		content = content + "#msgNotApplicable\n"
 		return content
 	}
 
@@ -49,6 +49,9 @@ func addContent(id string, cnt database.Content, imageAdder func(string) (string
 		case "richtext":
 			rich := addRichText(cnt.Value[i])
 			content = content + rich + "\n"
+		case "procedure":
+			procedure := addProcedureContent(cnt.FileName[i], cnt.Captions[i], cnt.Value[i], cnt.Landscape[i])
+			content = content + procedure + "\n"
 		default:
 			content = content + "unknown content type\n\n"
 		}
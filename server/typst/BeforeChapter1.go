@@ -5,7 +5,7 @@ import (
 	"time"
 )
 
-func getAllContentBeforeChapter1(id string, document database.DocumentDetails, subsystem database.SubsystemDetails, documentName string) (string, bool) {
+func getAllContentBeforeChapter1(id string, document database.DocumentDetails, subsystem database.SubsystemDetails, layout database.DocumentLayout, documentName string, user database.User, review bool) (string, bool) {
 	var content string
 	docNo := "#let docNum = \"" + document.DocumentNumber + "\"\n"
 	docTitle := "#let docTitle = \"IST Document for " + subsystem.SubsystemName + " system of " + subsystem.SatelliteName + "\"\n"
@@ -16,6 +16,7 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 	month := "#let month = \"" + monthGo + "\"\n"
 	ssName := "#let ssName = \"" + subsystem.SubsystemName + "\"\n"
 	satName := "#let satName = \"" + subsystem.SatelliteName + "\"\n"
+	satClass := "#let satClass = \"" + subsystem.SatelliteClass + "\"\n"
 	preparedBy := "#let preparedBy = \"" + document.PreparedBy + "\"\n"
 	reviewerName := "#let reviewerName = \"" + document.ReviewedByName + "\"\n"
 	reviewerTitle := "#let reviewerTitle = \"" + document.ReviewedByTitle + "\"\n"
@@ -27,23 +28,20 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 
 	content = docNo
 	content = content + docTitle + date + month + "\n"
-	content = content + ssName + satName + "\n"
+	content = content + ssName + satName + satClass + "\n"
 	content = content + preparedBy + reviewerName + reviewerTitle + "\n"
 	content = content + app1Name + app1Title + app2Name + app2Title + "\n"
+	content = content + getSignDates(documentName, user) + "\n"
+	content = content + getMessageDefinitions(document.Language) + "\n"
 
+	content = content + "#import \"@preview/cmarker:0.1.0\"\n"
+	content = content + getTextSettings(layout, document.Language)
+	content = content + getPageSettings(layout)
+	if review {
+		content = content + getReviewMark()
+	}
 	content = content + `
-	#import "@preview/cmarker:0.1.0"
-	#set heading(numbering: "1.1", supplement:[Chapter])
-	#set par(justify: true,leading:1.15em)
-	#set block(spacing:1.5em)
-	#set list(indent: 10pt)
-	#set text(font: "Roboto")
 	#set page(
-  		margin: (
-    		top: 4cm,
-    		x: 1.5cm,
-    		bottom:2cm
-  		),
   		header:
 		table(
   		columns: (10fr, 40fr, 20fr,30fr), 
@@ -79,8 +77,8 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 	#align(center)[
 		#text(18pt)[
 			#satName #linebreak()
-			Integrated Spacecraft Test Document #linebreak()
-			of #linebreak()
+			#msgTestDocument #linebreak()
+			#msgOf #linebreak()
 			#ssName #linebreak()
 		]
 	]
@@ -91,7 +89,7 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 	#linebreak()
 	`
 
-	page2, page2Image := getPage2Image(id, documentName)
+	page2, page2Image := getPage2Image(id, documentName, user)
 	if !page2Image {
 		page2 = getPage2Created()
 	}
@@ -101,46 +99,40 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 	content = content + `
 	#pagebreak()
 	#linebreak()
-	#text(size:18pt)[*Change History*]
+	#text(size:18pt)[*#msgChangeHistory*]
 	#table(
 		align:center,
 		columns:(1fr, 2fr, 2fr, 2fr, 2fr),
 		rows:10,
-		[*Version No*], [*Date*], [*Affected Section, Figure, Table*], [*Nature of Change[A, M, D]\**],[*Description*],
-		[1.0],[#month],[New],[New],[Initial Issue],
+		[*#msgVersionNo*], [*#msgDate*], [*#msgAffectedSection*], [*#msgNatureOfChange*],[*#msgDescription*],
+		[1.0],[#month],[#msgNew],[#msgNew],[#msgInitialIssue],
 	)
-	$*$ A - Addition, D - Deletion, M - Modification
+	#msgChangeLegend
 	#pagebreak()
 	#linebreak()
-	#text(size:18pt)[*Document Distribution List*]
-	#table(
-		align:center,
-		columns:(1fr, 2fr, 2fr),
-		rows:10,
-		[*Copy No*], [*Issued to*], [*Remarks*],
-		[1],[ISO Record],[Softcopy],
-		[2],[Master Copy Originator (Uncontrolled)],[Softcopy],
-		[3],[Committee Members],[Softcopy],
-	)
+	#text(size:18pt)[*#msgDistributionList*]
+	`
+	content = content + getDistributionTable(documentName, user)
+	content = content + `
 	#pagebreak()
 	#outline(
 		target:heading.where(supplement:[Chapter]),
-		title: [Table of Contents],
+		title: msgTableOfContents,
 		indent: auto,
 		depth: 3,
 	)
 	#outline(
 		target:heading.where(supplement:[Appendix]),
-		title: [Annexure],
+		title: msgAnnexure,
 		indent: auto
 	)
 	#pagebreak()
 	#outline(
-		title: [List of Figures],
+		title: msgListOfFigures,
 		target: figure.where(kind:image),
 	)
 	#outline(
-		title: [List of Tables],
+		title: msgListOfTables,
 		target: figure.where(kind:table),
 	)
 	#pagebreak()
@@ -150,7 +142,7 @@ func getAllContentBeforeChapter1(id string, document database.DocumentDetails, s
 
 }
 
-func getSignaturePage(id string, document database.DocumentDetails, subsystem database.SubsystemDetails) (string, bool) {
+func getSignaturePage(id string, document database.DocumentDetails, subsystem database.SubsystemDetails, layout database.DocumentLayout, documentName string, user database.User) (string, bool) {
 	var content string
 	docNo := "#let docNum = \"" + document.DocumentNumber + "\"\n"
 	docTitle := "#let docTitle = \"IST Document for " + subsystem.SubsystemName + " system of " + subsystem.SatelliteName + "\"\n"
@@ -161,6 +153,7 @@ func getSignaturePage(id string, document database.DocumentDetails, subsystem da
 	month := "#let month = \"" + monthGo + "\"\n"
 	ssName := "#let ssName = \"" + subsystem.SubsystemName + "\"\n"
 	satName := "#let satName = \"" + subsystem.SatelliteName + "\"\n"
+	satClass := "#let satClass = \"" + subsystem.SatelliteClass + "\"\n"
 	preparedBy := "#let preparedBy = \"" + document.PreparedBy + "\"\n"
 	reviewerName := "#let reviewerName = \"" + document.ReviewedByName + "\"\n"
 	reviewerTitle := "#let reviewerTitle = \"" + document.ReviewedByTitle + "\"\n"
@@ -171,48 +164,41 @@ func getSignaturePage(id string, document database.DocumentDetails, subsystem da
 
 	content = docNo
 	content = content + docTitle + date + month + "\n"
-	content = content + ssName + satName + "\n"
+	content = content + ssName + satName + satClass + "\n"
 	content = content + preparedBy + reviewerName + reviewerTitle + "\n"
 	content = content + app1Name + app1Title + app2Name + app2Title + "\n"
+	content = content + getSignDates(documentName, user) + "\n"
+	content = content + getMessageDefinitions(document.Language) + "\n"
 
+	content = content + "#import \"@preview/cmarker:0.1.0\"\n"
+	content = content + getTextSettings(layout, document.Language)
+	content = content + getPageSettings(layout)
 	content = content + `
-	#import "@preview/cmarker:0.1.0"
-	#set heading(numbering: "1.1", supplement:[Chapter])
-	#set par(justify: true,leading:1.15em)
-	#set block(spacing:1.5em)
-	#set list(indent: 10pt)
-	#set text(font: "Roboto")
-	#set page(
-  		margin: (
-    		top: 4cm,
-    		x: 1.5cm,
-    		bottom:2cm
-  		),
-	)
 	#linebreak()
 	#align(center)[
 		#text(18pt)[
 			#satName #linebreak()
-			Integrated Spacecraft Test Document #linebreak()
-			of #linebreak()
+			#msgTestDocument #linebreak()
+			#msgOf #linebreak()
 			#ssName #linebreak()
 		]
 	]
 	#v(1fr)
 	#align(center)[
-		Prepared By, #linebreak()
+		#msgPreparedBy, #linebreak()
 		#preparedBy
 	]
 	#v(1fr)
 	#align(center)[
-		Reviewed By, #linebreak()
+		#msgReviewedBy, #linebreak()
 		#linebreak()
 		#linebreak()
 		#reviewerName #linebreak()
 		#reviewerTitle
+		#if reviewedOn != "" [#linebreak() #msgDate: #reviewedOn]
 	]
 	#v(1fr)
-	#align(center)[Approved By,]
+	#align(center)[#msgApprovedBy,]
 	#linebreak()
 	#linebreak()
 	#grid(
@@ -220,19 +206,21 @@ func getSignaturePage(id string, document database.DocumentDetails, subsystem da
 		grid.cell(align:center)[
 			#app1Name , #linebreak()
 			#app1Title
+			#if app1On != "" [#linebreak() #msgDate: #app1On]
 		],
 		grid.cell(align:center)[
 			#app2Name , #linebreak()
 			#app2Title
+			#if app2On != "" [#linebreak() #msgDate: #app2On]
 		],
 	)
 	#v(1fr)
 	#align(center)[#month]
 	#v(1fr)
 	#align(center)[
-		U R Rao Satellite Center #linebreak()
-		Indian Space Research Organization #linebreak()
-		Bangalore
+		#msgCentre #linebreak()
+		#msgOrganisation #linebreak()
+		#msgCity
 	]
 	`
 	return content, true
@@ -243,26 +231,27 @@ func getPage2Created() string {
 	#align(center)[
 		#text(18pt)[
 			#satName #linebreak()
-			Integrated Spacecraft Test Document #linebreak()
-			of #linebreak()
+			#msgTestDocument #linebreak()
+			#msgOf #linebreak()
 			#ssName #linebreak()
 		]
 	]
 	#v(1fr)
 	#align(center)[
-		Prepared By, #linebreak()
+		#msgPreparedBy, #linebreak()
 		#preparedBy
 	]
 	#v(1fr)
 	#align(center)[
-		Reviewed By, #linebreak()
+		#msgReviewedBy, #linebreak()
 		#linebreak()
 		#linebreak()
 		#reviewerName #linebreak()
 		#reviewerTitle
+		#if reviewedOn != "" [#linebreak() #msgDate: #reviewedOn]
 	]
 	#v(1fr)
-	#align(center)[Approved By,]
+	#align(center)[#msgApprovedBy,]
 	#linebreak()
 	#linebreak()
 	#grid(
@@ -270,27 +259,29 @@ func getPage2Created() string {
 		grid.cell(align:center)[
 			#app1Name , #linebreak()
 			#app1Title
+			#if app1On != "" [#linebreak() #msgDate: #app1On]
 		],
 		grid.cell(align:center)[
 			#app2Name , #linebreak()
 			#app2Title
+			#if app2On != "" [#linebreak() #msgDate: #app2On]
 		],
 	)
 	#v(1fr)
 	#align(center)[#month]
 	#v(1fr)
 	#align(center)[
-		U R Rao Satellite Center #linebreak()
-		Indian Space Research Organization #linebreak()
-		Bangalore
+		#msgCentre #linebreak()
+		#msgOrganisation #linebreak()
+		#msgCity
 	]
 	`
 	return content
 }
 
-func getPage2Image(id string, documentName string) (string, bool) {
+func getPage2Image(id string, documentName string, user database.User) (string, bool) {
 	content := ""
-	errMsg, page2, ok := database.GetContent(documentName, "Information-SignedPage")
+	errMsg, page2, ok := database.GetContent(documentName, "Information-SignedPage", user)
 	if !ok {
 		content = content + "Error in Cehckout Interface: " + errMsg
 		return content, false
@@ -309,3 +300,24 @@ func getPage2Image(id string, documentName string) (string, bool) {
 	`
 	return content, true
 }
+
+// getSignDates defines the dates on which the reviewer and the approvers
+// signed off the document in the workflow, empty if they have not.
+func getSignDates(documentName string, user database.User) string {
+	_, workflow, _ := database.GetWorkflow(documentName, user)
+	content := "#let reviewedOn = \"" + getSignDate(workflow.ReviewedTime) + "\"\n"
+	content = content + "#let app1On = \"" + getSignDate(workflow.FirstApprovedTime) + "\"\n"
+	content = content + "#let app2On = \"" + getSignDate(workflow.SecondApprovedTime) + "\"\n"
+	return content
+}
+
+func getSignDate(signedAt string) string {
+	if len(signedAt) < 10 {
+		return ""
+	}
+	date, err := time.Parse("2006-01-02", signedAt[:10])
+	if err != nil {
+		return ""
+	}
+	return date.Format("02-Jan-2006")
+}
@@ -4,51 +4,56 @@ import (
 	"intDocument/server/database"
 )
 
-func makeCheckoutDetails(id string, documentName string, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
+func makeCheckoutDetails(id string, documentName string, user database.User, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
 	content := `
 	
-	= Checkout Details
+	= #msgCheckoutDetails
 	`
 
-	errMsg, inter, ok := database.GetContent(documentName, "Checkout-Interface")
+	errMsg, inter, ok := database.GetContent(documentName, "Checkout-Interface", user)
 	if !ok {
 		content = content + "Error in Cehckout Interface: " + errMsg
 		return content, false
 	}
 	interfaceContent := makeInterface(id, inter, imageAdder, pdfAdder, tableAdder)
-	content = content + interfaceContent
+	content = content + wrapSection(database.GetSectionLayout(layout, "Checkout-Interface"), interfaceContent)
 
-	errMsg, spec, ok := database.GetContent(documentName, "Checkout-SpecificRequirements")
+	errMsg, spec, ok := database.GetContent(documentName, "Checkout-SpecificRequirements", user)
 	if !ok {
 		content = content + "Error in Specific Requirements: " + errMsg
 		return content, false
 	}
-	specReq := makeSpecificRequirements(id, spec, imageAdder, pdfAdder, tableAdder)
-	content = content + specReq
+	errMsg, specList, ok := database.GetRequirements(documentName, "Checkout-SpecificRequirements", user)
+	if !ok {
+		content = content + "Error in Specific Requirements: " + errMsg
+		return content, false
+	}
+	specReq := makeSpecificRequirements(id, spec, specList, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "Checkout-SpecificRequirements"), specReq)
 
-	errMsg, safety, ok := database.GetContent(documentName, "Checkout-SafetyRequirements")
+	errMsg, safety, ok := database.GetContent(documentName, "Checkout-SafetyRequirements", user)
 	if !ok {
 		content = content + "Error in Safety Requirements: " + errMsg
 		return content, false
 	}
 	safetyReq := makeSafetyRequirements(id, safety, imageAdder, pdfAdder, tableAdder)
-	content = content + safetyReq
+	content = content + wrapSection(database.GetSectionLayout(layout, "Checkout-SafetyRequirements"), safetyReq)
 
-	errMsg, tp, ok := database.GetContent(documentName, "Checkout-TestPhilosophy")
+	errMsg, tp, ok := database.GetContent(documentName, "Checkout-TestPhilosophy", user)
 	if !ok {
 		content = content + "Error in Test Philosophy: " + errMsg
 		return content, false
 	}
 	telecommand := makeTestPhilosophy(id, tp, imageAdder, pdfAdder, tableAdder)
-	content = content + telecommand
+	content = content + wrapSection(database.GetSectionLayout(layout, "Checkout-TestPhilosophy"), telecommand)
 
-	errMsg, ssClar, ok := database.GetContent(documentName, "Checkout-SubsystemClarifications")
+	errMsg, ssClar, ok := database.GetContent(documentName, "Checkout-SubsystemClarifications", user)
 	if !ok {
 		content = content + "Error in Subsystem Clarification: " + errMsg
 		return content, false
 	}
 	ssCalrification := makeSubsystemClarification(id, ssClar, imageAdder, pdfAdder, tableAdder)
-	content = content + ssCalrification
+	content = content + wrapSection(database.GetSectionLayout(layout, "Checkout-SubsystemClarifications"), ssCalrification)
 
 	content = content + "#pagebreak()"
 	return content, true
@@ -56,24 +61,28 @@ func makeCheckoutDetails(id string, documentName string, imageAdder func(string)
 
 func makeInterface(id string, inter database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Checkout Interface
+	== #msgCheckoutInterface
 	`
 	interContent := addContent(id, inter, imageAdder, pdfAdder, tableAdder)
 	content = content + interContent
 	return content
 }
 
-func makeSpecificRequirements(id string, specReq database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := "#pagebreak()" + "\n"
-	content = content + `== Specific Requirements
+func makeSpecificRequirements(id string, specReq database.Content, specList database.RequirementList, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+	content := `== #msgSpecificRequirements
 	`
-	spec := addContent(id, specReq, imageAdder, pdfAdder, tableAdder)
-	content = content + spec
+	if specReq.NoOfItems > 0 || len(specList.Requirements) == 0 {
+		spec := addContent(id, specReq, imageAdder, pdfAdder, tableAdder)
+		content = content + spec
+	}
+	if len(specList.Requirements) > 0 {
+		content = content + "\n" + makeRequirementTable(specList, "msgSpecificRequirements") + "\n"
+	}
 	return content
 }
 
 func makeSafetyRequirements(id string, safetyReq database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := `== Safety Requirements
+	content := `== #msgSafetyRequirements
 	`
 	safety := addContent(id, safetyReq, imageAdder, pdfAdder, tableAdder)
 	content = content + safety
@@ -81,7 +90,7 @@ func makeSafetyRequirements(id string, safetyReq database.Content, imageAdder fu
 }
 
 func makeTestPhilosophy(id string, tp database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := `== Test Philosophy
+	content := `== #msgTestPhilosophy
 	`
 	testPhilosophy := addContent(id, tp, imageAdder, pdfAdder, tableAdder)
 	content = content + testPhilosophy
@@ -89,7 +98,7 @@ func makeTestPhilosophy(id string, tp database.Content, imageAdder func(string)
 }
 
 func makeSubsystemClarification(id string, ssClarifcation database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := `== Subsystem Clarification
+	content := `== #msgSubsystemClarification
 	`
 	ssClar := addContent(id, ssClarifcation, imageAdder, pdfAdder, tableAdder)
 	content = content + ssClar
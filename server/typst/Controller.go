@@ -7,21 +7,36 @@ import (
 	"os/exec"
 )
 
-func InitializeNewDocument(id string, documentName string) (string, bool) {
+func InitializeNewDocument(id string, documentName string, user database.User) (string, bool) {
+	return initializeDocument(id, documentName, user, false)
+}
+
+// InitializeReviewCopy writes the document marked as a review copy, with the
+// review comments as the last annexure.
+func InitializeReviewCopy(id string, documentName string, user database.User) (string, bool) {
+	return initializeDocument(id, documentName, user, true)
+}
+
+func initializeDocument(id string, documentName string, user database.User, review bool) (string, bool) {
 	var document = database.DocumentDetails{}
 	var subSystem = database.SubsystemDetails{}
 	var ok bool
 	var errMsg string
-	errMsg, document, ok = database.GetDocumentDetails(documentName)
+	errMsg, document, ok = database.GetDocumentDetails(documentName, user)
 	if !ok {
 		fmt.Println(errMsg)
 		return "Document doesn't exist", false
 	}
-	errMsg, subSystem, ok = database.GetSubsystemDetails(documentName)
+	errMsg, subSystem, ok = database.GetSubsystemDetails(documentName, user)
 	if !ok {
 		fmt.Println(errMsg)
 		return "Document doesn't exist", false
 	}
+	errMsg, layout, ok := database.GetLayout(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Layout", false
+	}
 	err := os.MkdirAll(id, os.ModePerm)
 	if err != nil {
 		fmt.Println("Cannot Create Directory")
@@ -49,42 +64,63 @@ func InitializeNewDocument(id string, documentName string) (string, bool) {
 	pdfAdder := getPDFAdder(id)
 	tableAdder := getTableNumber()
 
-	contentBefore, ok := getAllContentBeforeChapter1(id, document, subSystem, documentName)
+	contentBefore, ok := getAllContentBeforeChapter1(id, document, subSystem, layout, documentName, user, review)
 	if !ok {
 		return "Cannot make Main file", false
 	}
 
-	introContent, ok := makeIntroduction(id, documentName, imageAdder, pdfAdder, tableAdder)
+	introContent, ok := makeIntroduction(id, documentName, user, layout, imageAdder, pdfAdder, tableAdder)
 	if !ok {
 		return "Cannot create introduction file", false
 	}
 
-	checkoutContent, ok := makeCheckoutDetails(id, documentName, imageAdder, pdfAdder, tableAdder)
+	checkoutContent, ok := makeCheckoutDetails(id, documentName, user, layout, imageAdder, pdfAdder, tableAdder)
 	if !ok {
 		return "Cannot create Checkout Details page", false
 	}
-	testDetails, ok := makeTestDetails(id, documentName, imageAdder, pdfAdder, tableAdder)
+	testDetails, ok := makeTestDetails(id, documentName, user, layout, imageAdder, pdfAdder, tableAdder)
 	if !ok {
 		return "Cannot create Test Details page", false
 	}
-	eidContent, ok := makeEID(id, documentName, imageAdder, pdfAdder, tableAdder)
+	eidContent, ok := makeEID(id, documentName, user, layout, imageAdder, pdfAdder, tableAdder)
 	if !ok {
 		return "Cannot create EID page", false
 	}
-	resultContent, ok := makeTestResults(id, documentName, imageAdder, pdfAdder, tableAdder)
+	resultContent, ok := makeTestResults(id, documentName, user, layout, imageAdder, pdfAdder, tableAdder)
 	if !ok {
 		return "Cannot create Test Results page", false
 	}
+	traceContent, ok := makeTraceability(documentName, user)
+	if !ok {
+		return "Cannot create Traceability Matrix page", false
+	}
+	mnemonicContent := ""
+	if document.MnemonicCheck {
+		mnemonicContent, ok = makeMnemonicCheck(documentName, user)
+		if !ok {
+			return "Cannot create Mnemonic Check page", false
+		}
+	}
+	reviewContent := ""
+	if review {
+		reviewContent, ok = makeReviewComments(documentName, user, document.Language)
+		if !ok {
+			return "Cannot create Review Comments page", false
+		}
+	}
 
 	fullContent := ""
 	fullContent = fullContent + contentBefore + "\n"
 	fullContent = fullContent + introContent + "\n"
 	fullContent = fullContent + checkoutContent + "\n"
 	fullContent = fullContent + testDetails + "\n"
-	fullContent = fullContent + "#set heading(numbering: none, supplement:none, outlined:false, bookmarked:false)\n= Annexure\n\n"
+	fullContent = fullContent + "#set heading(numbering: none, supplement:none, outlined:false, bookmarked:false)\n= #msgAnnexure\n\n"
 	fullContent = fullContent + "#show: appendix\n\n"
 	fullContent = fullContent + eidContent + "\n"
 	fullContent = fullContent + resultContent + "\n"
+	fullContent = fullContent + traceContent + "\n"
+	fullContent = fullContent + mnemonicContent + "\n"
+	fullContent = fullContent + reviewContent + "\n"
 
 	typstFile := id + "/main.typ"
 	err = os.WriteFile(typstFile, []byte(fullContent), 0666)
@@ -100,6 +136,7 @@ func Compile(id string) ([]byte, bool) {
 	cmd := "typst"
 	options := make([]string, 0)
 	options = append(options, "compile")
+	options = append(options, getResourceOptions()...)
 	options = append(options, "main.typ")
 	command := exec.Command(cmd, options...)
 	command.Dir = "./" + id + "/"
@@ -125,21 +162,26 @@ func removeFolder(id string) {
 	}
 }
 
-func GetSignaturePage(id string, documentName string) (string, bool) {
+func GetSignaturePage(id string, documentName string, user database.User) (string, bool) {
 	var document = database.DocumentDetails{}
 	var subSystem = database.SubsystemDetails{}
 	var ok bool
 	var errMsg string
-	errMsg, document, ok = database.GetDocumentDetails(documentName)
+	errMsg, document, ok = database.GetDocumentDetails(documentName, user)
 	if !ok {
 		fmt.Println(errMsg)
 		return "Document doesn't exist", false
 	}
-	errMsg, subSystem, ok = database.GetSubsystemDetails(documentName)
+	errMsg, subSystem, ok = database.GetSubsystemDetails(documentName, user)
 	if !ok {
 		fmt.Println(errMsg)
 		return "Document doesn't exist", false
 	}
+	errMsg, layout, ok := database.GetLayout(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Layout", false
+	}
 	err := os.MkdirAll(id, os.ModePerm)
 	if err != nil {
 		fmt.Println("Cannot Create Directory")
@@ -164,7 +206,7 @@ func GetSignaturePage(id string, documentName string) (string, bool) {
 		return "Cannot copy Logo", false
 	}
 
-	sign, ok := getSignaturePage(id, document, subSystem)
+	sign, ok := getSignaturePage(id, document, subSystem, layout, documentName, user)
 	if !ok {
 		return "Cannot make Main file", false
 	}
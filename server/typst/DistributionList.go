@@ -0,0 +1,98 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"os"
+	"strconv"
+)
+
+func getDistributionTable(documentName string, user database.User) string {
+	errMsg, list, ok := database.GetDistributionList(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Error in Distribution List: " + errMsg + "\n"
+	}
+	content := `
+	#table(
+		align:center,
+		columns:(1fr, 2fr, 2fr, 2fr),
+		[*#msgCopyNo*], [*#msgIssuedTo*], [*#msgMedium*], [*#msgRemarks*],
+	`
+	for _, entry := range list.Entries {
+		content = content + "[" + strconv.Itoa(entry.CopyNo) + "], "
+		content = content + quoteString(entry.IssuedTo) + ", "
+		content = content + quoteString(entry.Medium) + ", "
+		content = content + quoteString(entry.Remarks) + ",\n"
+	}
+	content = content + ")\n"
+	return content
+}
+
+func getDistributionRegister(document database.DocumentDetails, subsystem database.SubsystemDetails, layout database.DocumentLayout, list database.DistributionList) string {
+	content := "#let docNum = " + quoteString(document.DocumentNumber) + "\n"
+	content = content + "#let docTitle = " + quoteString("IST Document for "+subsystem.SubsystemName+" system of "+subsystem.SatelliteName) + "\n"
+	content = content + "#let ssName = " + quoteString(subsystem.SubsystemName) + "\n"
+	content = content + "#let satName = " + quoteString(subsystem.SatelliteName) + "\n"
+	content = content + "#let satClass = " + quoteString(subsystem.SatelliteClass) + "\n"
+	content = content + getMessageDefinitions(document.Language)
+	content = content + getTextSettings(layout, document.Language)
+	content = content + getPageSettings(layout)
+	content = content + `
+	#align(center)[
+		#text(18pt)[*Controlled Copy Register*] #linebreak()
+		#docTitle #linebreak()
+		Document No: #docNum
+	]
+	#v(1em)
+	#table(
+		align:center,
+		columns:(1fr, 3fr, 2fr, 2fr, 3fr),
+		table.header(repeat: true,)[*#msgCopyNo*][*#msgIssuedTo*][*#msgMedium*][*#msgRemarks*][*Received By (Sign & Date)*],
+	`
+	for _, entry := range list.Entries {
+		content = content + "[" + strconv.Itoa(entry.CopyNo) + "], "
+		content = content + quoteString(entry.IssuedTo) + ", "
+		content = content + quoteString(entry.Medium) + ", "
+		content = content + quoteString(entry.Remarks) + ", [#v(2em)],\n"
+	}
+	content = content + ")\n"
+	return content
+}
+
+func GetDistributionRegister(id string, documentName string, user database.User) (string, bool) {
+	errMsg, document, ok := database.GetDocumentDetails(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Document doesn't exist", false
+	}
+	errMsg, subSystem, ok := database.GetSubsystemDetails(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Document doesn't exist", false
+	}
+	errMsg, list, ok := database.GetDistributionList(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Distribution List", false
+	}
+	errMsg, layout, ok := database.GetLayout(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Layout", false
+	}
+	err := os.MkdirAll(id, os.ModePerm)
+	if err != nil {
+		fmt.Println("Cannot Create Directory")
+		return "Cannot create Client Directory", false
+	}
+
+	fullContent := getDistributionRegister(document, subSystem, layout, list)
+
+	typstFile := id + "/main.typ"
+	err = os.WriteFile(typstFile, []byte(fullContent), 0666)
+	if err != nil {
+		return "Cannot write Typst file", false
+	}
+	return "", true
+}
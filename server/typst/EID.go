@@ -4,18 +4,18 @@ import (
 	"intDocument/server/database"
 )
 
-func makeEID(id string, documentName string, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
+func makeEID(id string, documentName string, user database.User, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
 	content := `
-	= EID
+	= #msgEID
 	`
 
-	errMsg, eid, ok := database.GetContent(documentName, "Annexure-EID")
+	errMsg, eid, ok := database.GetContent(documentName, "Annexure-EID", user)
 	if !ok {
 		content = content + "Error in getting EID: " + errMsg
 		return "", false
 	}
 	eidContent := addContent(id, eid, imageAdder, pdfAdder, tableAdder)
-	content = content + eidContent + "\n\n"
+	content = content + wrapSection(database.GetSectionLayout(layout, "Annexure-EID"), eidContent) + "\n\n"
 	content = content + "#pagebreak()"
 
 	return content, true
@@ -82,11 +82,17 @@ func getTableNumber() func() int {
 }
 
 func addTable(table string, caption string, landscape bool, tableNo int) string {
+	return addTableWithCaption(table, "\""+caption+"\"", landscape, tableNo)
+}
+
+// addTableWithCaption works like addTable, with the caption given as a Typst
+// expression instead of a plain string.
+func addTableWithCaption(table string, caption string, landscape bool, tableNo int) string {
 	lines := strings.Split(table, "\n")
 	colNames := strings.Split(lines[0], ",")
 	header := "table.header(repeat: true,)"
 	colSpec := "columns: (50pt,"
-	header = header + "[*Sl. No*]"
+	header = header + "[*#msgSlNo*]"
 	for _, col := range colNames {
 		header = header + "[*" + col + "*]"
 		colSpec = colSpec + "auto,"
@@ -119,7 +125,7 @@ func addTable(table string, caption string, landscape bool, tableNo int) string
 	content = content + header
 	content = content + ".." + tableName + ".flatten(),\n"
 	content = content + "),\n"
-	content = content + "caption: \"" + caption + "\",\n"
+	content = content + "caption: " + caption + ",\n"
 	content = content + ")\n"
 	if landscape {
 		content = content + "]\n"
@@ -395,3 +401,45 @@ func getTypstStringForDelta(delta Delta) string {
 	}
 	return tbr
 }
+
+// quoteString returns text as a Typst string literal, so that user entered
+// values can be placed in tables without being parsed as markup.
+func quoteString(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "\"", "\\\"")
+	text = strings.ReplaceAll(text, "\r", "")
+	text = strings.ReplaceAll(text, "\n", "\\n")
+	return "\"" + text + "\""
+}
+
+// addRecordTable returns a numbered table of structured records. headers and
+// caption are Typst markup, the cells are quoted as plain strings.
+func addRecordTable(headers []string, rows [][]string, caption string) string {
+	colSpec := "columns: (50pt,"
+	header := "table.header(repeat: true,)[*#msgSlNo*]"
+	for _, col := range headers {
+		header = header + "[*" + col + "*]"
+		colSpec = colSpec + "auto,"
+	}
+	header = header + ",\n"
+	colSpec = colSpec + "),\n"
+
+	rowData := ""
+	for i, row := range rows {
+		rowData = rowData + "[" + strconv.Itoa(i+1) + "], "
+		for _, cell := range row {
+			rowData = rowData + quoteString(cell) + ", "
+		}
+		rowData = rowData + "\n"
+	}
+
+	content := "#show figure: set block(breakable: true)\n"
+	content = content + "#figure(table(\n"
+	content = content + colSpec
+	content = content + header
+	content = content + rowData
+	content = content + "),\n"
+	content = content + "caption: " + caption + ",\n"
+	content = content + ")\n"
+	return content
+}
@@ -1,94 +1,86 @@
 package typst
 
 import (
-	"fmt"
 	"intDocument/server/database"
 )
 
-func makeIntroduction(id string, documentName string, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
-	errMsg, subSystem, ok := database.GetSubsystemDetails(documentName)
-	if !ok {
-		fmt.Println(errMsg)
-		return "", false
-	}
+func makeIntroduction(id string, documentName string, user database.User, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
 	content := `
-	= Introduction
+	= #msgIntroduction
 	`
-	abstract := makeAbstract(subSystem)
+	abstract := makeAbstract()
 	content = content + abstract + "\n"
 
-	errMsg, acronyms, ok := database.GetContent(documentName, "Introduction-Acronyms")
+	errMsg, acronyms, ok := database.GetContent(documentName, "Introduction-Acronyms", user)
 	if !ok {
 		content = content + "Error in Subsystem Introduction: " + errMsg
 	}
 	acro := makeAcronyms(id, acronyms, imageAdder, pdfAdder, tableAdder)
-	content = content + acro
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-Acronyms"), acro)
 
-	errMsg, introContent, ok := database.GetContent(documentName, "Introduction-SSIntroduction")
+	errMsg, introContent, ok := database.GetContent(documentName, "Introduction-SSIntroduction", user)
 	if !ok {
 		content = content + "Error in Subsystem Introduction: " + errMsg
 	}
 	ssIntro := makeSSIntroduction(id, introContent, imageAdder, pdfAdder, tableAdder)
-	content = content + ssIntro
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-SSIntroduction"), ssIntro)
 
-	errMsg, specContent, ok := database.GetContent(documentName, "Introduction-SSSpecification")
+	errMsg, specContent, ok := database.GetContent(documentName, "Introduction-SSSpecification", user)
+	if !ok {
+		content = content + "Error in Subsystem Specification: " + errMsg
+	}
+	errMsg, specList, ok := database.GetRequirements(documentName, "Introduction-SSSpecification", user)
 	if !ok {
 		content = content + "Error in Subsystem Specification: " + errMsg
 	}
-	ssSpec := makeSSSpecification(id, specContent, imageAdder, pdfAdder, tableAdder)
-	content = content + ssSpec
+	ssSpec := makeSSSpecification(id, specContent, specList, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-SSSpecification"), ssSpec)
 
-	errMsg, tc, ok := database.GetContent(documentName, "Introduction-Telecommand")
+	errMsg, tc, ok := database.GetContent(documentName, "Introduction-Telecommand", user)
 	if !ok {
 		content = content + "Error in Telecommand: " + errMsg
 	}
-	telecommand := makeTelecommand(id, tc, imageAdder, pdfAdder, tableAdder)
-	content = content + telecommand
+	errMsg, tcList, ok := database.GetTelecommands(documentName, user)
+	if !ok {
+		content = content + "Error in Telecommand List: " + errMsg
+	}
+	telecommand := makeTelecommand(id, tc, tcList, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-Telecommand"), telecommand)
 
-	errMsg, tm, ok := database.GetContent(documentName, "Introduction-Telemetry")
+	errMsg, tm, ok := database.GetContent(documentName, "Introduction-Telemetry", user)
 	if !ok {
 		content = content + "Error in Telemetry: " + errMsg
 	}
-	telemetry := makeTelemetry(id, tm, imageAdder, pdfAdder, tableAdder)
-	content = content + telemetry
+	errMsg, tmList, ok := database.GetTelemetry(documentName, user)
+	if !ok {
+		content = content + "Error in Telemetry List: " + errMsg
+	}
+	telemetry := makeTelemetry(id, tm, tmList, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-Telemetry"), telemetry)
 
-	errMsg, pages, ok := database.GetContent(documentName, "Introduction-Pages")
+	errMsg, pages, ok := database.GetContent(documentName, "Introduction-Pages", user)
 	if !ok {
 		content = content + "Error in Pages: " + errMsg
 	}
-	page := makePages(id, pages, imageAdder, pdfAdder, tableAdder)
-	content = content + page
+	page := makePages(id, pages, tmList, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "Introduction-Pages"), page)
 
 	content = content + "#pagebreak()"
 
 	return content, true
 }
 
-func makeAbstract(subSystem database.SubsystemDetails) string {
+func makeAbstract() string {
 	content := `
-	== Abstract
-	This document briefly describes the #ssName of #satName an `
-	content = content + subSystem.SatelliteClass
-	content = content + " class of Satellite, and gives all aspects related to Integrated satellite test(IST), namely\n"
-	content = content + `	
-		- Mnemonics for TM and TC
-		- TM Pages
-		- Possible status displays for TM parameters
-		- IST test matrix
-		- IST plans
-		- IST Procedures
-		- IST Test Report Formats
-		- Any Specific Requirements
-
-
-	Above aspects are covered in various chapters as given in the contents.
+	== #msgAbstract
+	#msgAbstractText
 	`
 	return content
 }
 
 func makeAcronyms(id string, acro database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Acronyms
+	== #msgAcronyms
 	`
 	introduction := addContent(id, acro, imageAdder, pdfAdder, tableAdder)
 	content = content + introduction
@@ -97,48 +89,106 @@ func makeAcronyms(id string, acro database.Content, imageAdder func(string) (str
 
 func makeSSIntroduction(id string, intro database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Introduction to Subsystem
+	== #msgSSIntroduction
 	`
 	introduction := addContent(id, intro, imageAdder, pdfAdder, tableAdder)
 	content = content + introduction
 	return content
 }
 
-func makeSSSpecification(id string, spec database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+func makeSSSpecification(id string, spec database.Content, specList database.RequirementList, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Specification of Subsystem
+	== #msgSSSpecification
 	`
-	introduction := addContent(id, spec, imageAdder, pdfAdder, tableAdder)
-	content = content + introduction
+	if spec.NoOfItems > 0 || len(specList.Requirements) == 0 {
+		introduction := addContent(id, spec, imageAdder, pdfAdder, tableAdder)
+		content = content + introduction
+	}
+	if len(specList.Requirements) > 0 {
+		content = content + "\n" + makeRequirementTable(specList, "msgSSSpecification") + "\n"
+	}
 	return content
 }
 
-func makeTelecommand(id string, tc database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := "#pagebreak()" + "\n"
-	content = content + `
-	== Telecommand Details
+func makeRequirementTable(list database.RequirementList, caption string) string {
+	headers := []string{"#msgRequirementID", "#msgRequirement"}
+	rows := make([][]string, 0)
+	for _, req := range list.Requirements {
+		rows = append(rows, []string{req.ID, req.Text})
+	}
+	return addRecordTable(headers, rows, caption)
+}
+
+func makeTelecommand(id string, tc database.Content, tcList database.TelecommandList, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+	content := `
+	== #msgTelecommandDetails
 	`
-	introduction := addContent(id, tc, imageAdder, pdfAdder, tableAdder)
-	content = content + introduction
+	if tc.NoOfItems > 0 || len(tcList.Telecommands) == 0 {
+		introduction := addContent(id, tc, imageAdder, pdfAdder, tableAdder)
+		content = content + introduction
+	}
+	if len(tcList.Telecommands) > 0 {
+		content = content + "\n" + makeTelecommandTable(tcList) + "\n"
+	}
 	return content
 }
 
-func makeTelemetry(id string, tm database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := "#pagebreak()" + "\n"
-	content = content + `
-	== Telemetry Details
+func makeTelecommandTable(tcList database.TelecommandList) string {
+	headers := []string{"#msgMnemonic", "#msgCode", "#msgDescription", "#msgType", "#msgVerificationTM", "#msgCriticality"}
+	rows := make([][]string, 0)
+	for _, tc := range tcList.Telecommands {
+		rows = append(rows, []string{tc.Mnemonic, tc.Code, tc.Description, tc.Type, tc.VerificationTM, tc.Criticality})
+	}
+	return addRecordTable(headers, rows, "msgTelecommandList")
+}
+
+func makeTelemetry(id string, tm database.Content, tmList database.TelemetryList, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+	content := `
+	== #msgTelemetryDetails
 	`
-	introduction := addContent(id, tm, imageAdder, pdfAdder, tableAdder)
-	content = content + introduction
+	if tm.NoOfItems > 0 || len(tmList.Parameters) == 0 {
+		introduction := addContent(id, tm, imageAdder, pdfAdder, tableAdder)
+		content = content + introduction
+	}
+	if len(tmList.Parameters) > 0 {
+		content = content + "\n" + makeTelemetryTable(tmList) + "\n"
+	}
 	return content
 }
 
-func makePages(id string, page database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := "#pagebreak()" + "\n"
-	content = content + `
-	== Pages
+func makeTelemetryTable(tmList database.TelemetryList) string {
+	headers := []string{"#msgMnemonic", "#msgChannel", "#msgUnits", "#msgCalibration", "#msgLowerLimit", "#msgUpperLimit"}
+	rows := make([][]string, 0)
+	for _, tm := range tmList.Parameters {
+		rows = append(rows, []string{tm.Mnemonic, tm.Channel, tm.Units, tm.Calibration, tm.LowerLimit, tm.UpperLimit})
+	}
+	return addRecordTable(headers, rows, "msgTelemetryList")
+}
+
+func makePages(id string, page database.Content, tmList database.TelemetryList, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+	content := `
+	== #msgPages
 	`
-	introduction := addContent(id, page, imageAdder, pdfAdder, tableAdder)
-	content = content + introduction
+	statusTable := makeStatusTable(tmList)
+	if page.NoOfItems > 0 || statusTable == "" {
+		introduction := addContent(id, page, imageAdder, pdfAdder, tableAdder)
+		content = content + introduction
+	}
+	content = content + statusTable
 	return content
 }
+
+// makeStatusTable lists the status words of every parameter that has them.
+func makeStatusTable(tmList database.TelemetryList) string {
+	headers := []string{"#msgMnemonic", "#msgValue", "#msgStatus"}
+	rows := make([][]string, 0)
+	for _, tm := range tmList.Parameters {
+		for _, word := range tm.StatusWords {
+			rows = append(rows, []string{tm.Mnemonic, word.Value, word.Status})
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return "\n" + addRecordTable(headers, rows, "msgStatusDisplays") + "\n"
+}
@@ -0,0 +1,80 @@
+package typst
+
+import (
+	"intDocument/server/database"
+	"strconv"
+	"strings"
+)
+
+func getPaperName(paperSize string) string {
+	switch strings.ToLower(paperSize) {
+	case "letter":
+		return "us-letter"
+	case "a3":
+		return "a3"
+	default:
+		return "a4"
+	}
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// getTextSettings returns the set rules for headings, paragraphs and text
+// that are common to every generated file. Devanagari glyphs fall back to
+// the Devanagari font of the layout.
+func getTextSettings(layout database.DocumentLayout, language string) string {
+	numbering := "none"
+	if len(strings.TrimSpace(layout.HeadingNumbering)) > 0 {
+		numbering = quoteString(layout.HeadingNumbering)
+	}
+	content := "#set heading(numbering: " + numbering + ", supplement:[Chapter])\n"
+	content = content + "#set par(justify: true,leading:" + formatNumber(layout.LineSpacing) + "em)\n"
+	content = content + "#set block(spacing:" + formatNumber(layout.ParagraphSpacing) + "em)\n"
+	content = content + "#set list(indent: 10pt)\n"
+	devanagariFont := layout.DevanagariFont
+	if len(strings.TrimSpace(devanagariFont)) == 0 {
+		devanagariFont = "Noto Sans Devanagari"
+	}
+	content = content + "#set text(font: (" + quoteString(layout.FontFamily) + ", " + quoteString(devanagariFont) + "), size: " + formatNumber(layout.FontSize) + "pt)\n"
+	if strings.EqualFold(language, "hi") {
+		content = content + "#set text(lang: \"hi\")\n"
+	}
+	return content
+}
+
+func getPageSettings(layout database.DocumentLayout) string {
+	content := "#set page(\n"
+	content = content + "\tpaper: \"" + getPaperName(layout.PaperSize) + "\",\n"
+	content = content + "\tmargin: (\n"
+	content = content + "\t\ttop: " + formatNumber(layout.MarginTop) + "cm,\n"
+	content = content + "\t\tx: " + formatNumber(layout.MarginSide) + "cm,\n"
+	content = content + "\t\tbottom: " + formatNumber(layout.MarginBottom) + "cm\n"
+	content = content + "\t),\n"
+	content = content + ")\n"
+	return content
+}
+
+// wrapSection applies the orientation, column count and new page setting of
+// a subsection around its content.
+func wrapSection(section database.SectionLayout, content string) string {
+	wrapped := "\n"
+	if section.NewPage {
+		wrapped = wrapped + "#pagebreak(weak: true)\n"
+	}
+	landscape := strings.EqualFold(section.Orientation, "landscape")
+	changesPage := landscape || section.Columns > 1
+	if changesPage {
+		columns := section.Columns
+		if columns < 1 {
+			columns = 1
+		}
+		wrapped = wrapped + "#set page(flipped: " + strconv.FormatBool(landscape) + ", columns: " + strconv.Itoa(columns) + ")\n"
+	}
+	wrapped = wrapped + content
+	if changesPage {
+		wrapped = wrapped + "\n#set page(flipped: false, columns: 1)\n"
+	}
+	return wrapped
+}
@@ -0,0 +1,311 @@
+package typst
+
+import (
+	"sort"
+	"strings"
+)
+
+// messages is the catalogue of fixed text used in the generated document.
+// Every entry is Typst markup and is defined in the preamble as a variable
+// named "msg" followed by the key, e.g. #msgCheckoutDetails.
+var messages = map[string]map[string]string{
+	"en": {
+		"TestDocument":           "Integrated Spacecraft Test Document",
+		"Of":                     "of",
+		"PreparedBy":             "Prepared By",
+		"ReviewedBy":             "Reviewed By",
+		"ApprovedBy":             "Approved By",
+		"Centre":                 "U R Rao Satellite Center",
+		"Organisation":           "Indian Space Research Organization",
+		"City":                   "Bangalore",
+		"ChangeHistory":          "Change History",
+		"VersionNo":              "Version No",
+		"Date":                   "Date",
+		"AffectedSection":        "Affected Section, Figure, Table",
+		"NatureOfChange":         "Nature of Change[A, M, D]\\*",
+		"Description":            "Description",
+		"New":                    "New",
+		"InitialIssue":           "Initial Issue",
+		"ChangeLegend":           "$*$ A - Addition, D - Deletion, M - Modification",
+		"DistributionList":       "Document Distribution List",
+		"CopyNo":                 "Copy No",
+		"IssuedTo":               "Issued to",
+		"Medium":                 "Medium",
+		"Remarks":                "Remarks",
+		"TableOfContents":        "Table of Contents",
+		"Annexure":               "Annexure",
+		"ListOfFigures":          "List of Figures",
+		"ListOfTables":           "List of Tables",
+		"Introduction":           "Introduction",
+		"Abstract":               "Abstract",
+		"Acronyms":               "Acronyms",
+		"SSIntroduction":         "Introduction to Subsystem",
+		"SSSpecification":        "Specification of Subsystem",
+		"TelecommandDetails":     "Telecommand Details",
+		"TelemetryDetails":       "Telemetry Details",
+		"Pages":                  "Pages",
+		"CheckoutDetails":        "Checkout Details",
+		"CheckoutInterface":      "Checkout Interface",
+		"SpecificRequirements":   "Specific Requirements",
+		"SafetyRequirements":     "Safety Requirements",
+		"TestPhilosophy":         "Test Philosophy",
+		"SubsystemClarification": "Subsystem Clarification",
+		"TestDetails":            "Test Details",
+		"TestMatrix":             "Test Matrix",
+		"TestPlan":               "Test Plan",
+		"TestProcedures":         "Test Procedures",
+		"ProcedureList":          "Procedure List",
+		"Title":                  "Title",
+		"Procedure":              "Procedure",
+		"EID":                    "EID",
+		"TestResultFormat":       "Test Result Format",
+		"SlNo":                   "Sl. No",
+		"NotApplicable":          "Not Applicable",
+		"TelecommandList":        "Telecommand List",
+		"Mnemonic":               "Mnemonic",
+		"Code":                   "Code",
+		"Type":                   "Type",
+		"VerificationTM":         "Verification TM",
+		"Criticality":            "Criticality",
+		"TelemetryList":          "Telemetry Parameter List",
+		"Channel":                "Channel",
+		"Units":                  "Units",
+		"Calibration":            "Calibration",
+		"LowerLimit":             "Lower Limit",
+		"UpperLimit":             "Upper Limit",
+		"StatusDisplays":         "Possible Status Displays",
+		"Value":                  "Value",
+		"Status":                 "Status",
+		"Action":                 "Action",
+		"TCToSend":               "TC to Send",
+		"TMToVerify":             "TM to Verify",
+		"ExpectedValue":          "Expected Value",
+		"Tolerance":              "Tolerance",
+		"WaitTime":               "Wait Time",
+		"Configuration":          "Configuration",
+		"RequirementID":          "Requirement ID",
+		"Requirement":            "Requirement",
+		"Procedures":             "Procedures",
+		"Traceability":           "Requirements Traceability Matrix",
+		"UncoveredRequirements":  "Requirements not covered by any Procedure",
+		"UnknownRequirements":    "Requirements referenced by Procedures but not defined",
+		"UnreadableProcedures":   "Procedures left out because they cannot be read",
+		"TestReport":             "Integrated Spacecraft Test Report",
+		"Summary":                "Summary",
+		"TestDate":               "Test Date",
+		"Operator":               "Operator",
+		"Passed":                 "Passed",
+		"Failed":                 "Failed",
+		"NotRun":                 "Not Run",
+		"Observed":               "Observed Value",
+		"Result":                 "Result",
+		"AnomalyRef":             "Anomaly Reference",
+		"OutOfLimits":            "Out of Limit Samples",
+		"Unattributed":           "Unattributed",
+		"Time":                   "Time",
+		"Step":                   "Step",
+		"ReviewComments":         "Review Comments",
+		"ReviewCopy":             "REVIEW COPY",
+		"Section":                "Section",
+		"Item":                   "Item",
+		"Comment":                "Comment",
+		"Author":                 "Author",
+		"Replies":                "Replies",
+		"Open":                   "Open",
+		"Resolved":               "Resolved",
+		"ItemRemoved":            "Removed",
+		"MnemonicCheck":          "Mnemonic Cross-Check",
+		"UndefinedMnemonics":     "Mnemonics not defined in the TC/TM Lists",
+		"MisspelledMnemonics":    "Possibly Misspelled Mnemonics",
+		"Suggestion":             "Did you mean",
+		"UnusedTelecommands":     "Telecommands not used in any Procedure",
+		"UnusedTelemetry":        "Telemetry Parameters not used in any Procedure",
+		"NoMnemonicProblems":     "All mnemonics used in the procedures are defined, and every telecommand and telemetry parameter is used.",
+		"AbstractText": `This document briefly describes the #ssName of #satName an #satClass class of Satellite, and gives all aspects related to Integrated satellite test(IST), namely
+	- Mnemonics for TM and TC
+	- TM Pages
+	- Possible status displays for TM parameters
+	- IST test matrix
+	- IST plans
+	- IST Procedures
+	- IST Test Report Formats
+	- Any Specific Requirements
+
+	Above aspects are covered in various chapters as given in the contents.`,
+	},
+	"hi": {
+		"TestDocument":           "एकीकृत अंतरिक्षयान परीक्षण दस्तावेज़",
+		"Of":                     "का",
+		"PreparedBy":             "द्वारा तैयार",
+		"ReviewedBy":             "द्वारा समीक्षित",
+		"ApprovedBy":             "द्वारा अनुमोदित",
+		"Centre":                 "यू आर राव उपग्रह केंद्र",
+		"Organisation":           "भारतीय अंतरिक्ष अनुसंधान संगठन",
+		"City":                   "बेंगलूरु",
+		"ChangeHistory":          "परिवर्तन इतिहास",
+		"VersionNo":              "संस्करण सं.",
+		"Date":                   "दिनांक",
+		"AffectedSection":        "प्रभावित खंड, चित्र, तालिका",
+		"NatureOfChange":         "परिवर्तन का प्रकार [A, M, D]\\*",
+		"Description":            "विवरण",
+		"New":                    "नया",
+		"InitialIssue":           "प्रारंभिक अंक",
+		"ChangeLegend":           "$*$ A - जोड़, D - विलोपन, M - संशोधन",
+		"DistributionList":       "दस्तावेज़ वितरण सूची",
+		"CopyNo":                 "प्रति सं.",
+		"IssuedTo":               "जारी किया गया",
+		"Medium":                 "माध्यम",
+		"Remarks":                "टिप्पणी",
+		"TableOfContents":        "विषय सूची",
+		"Annexure":               "अनुबंध",
+		"ListOfFigures":          "चित्रों की सूची",
+		"ListOfTables":           "तालिकाओं की सूची",
+		"Introduction":           "परिचय",
+		"Abstract":               "सारांश",
+		"Acronyms":               "संक्षिप्ताक्षर",
+		"SSIntroduction":         "उपप्रणाली का परिचय",
+		"SSSpecification":        "उपप्रणाली के विनिर्देश",
+		"TelecommandDetails":     "दूरादेश विवरण",
+		"TelemetryDetails":       "दूरमिति विवरण",
+		"Pages":                  "पृष्ठ",
+		"CheckoutDetails":        "जाँच विवरण",
+		"CheckoutInterface":      "जाँच इंटरफ़ेस",
+		"SpecificRequirements":   "विशिष्ट आवश्यकताएँ",
+		"SafetyRequirements":     "सुरक्षा आवश्यकताएँ",
+		"TestPhilosophy":         "परीक्षण दर्शन",
+		"SubsystemClarification": "उपप्रणाली स्पष्टीकरण",
+		"TestDetails":            "परीक्षण विवरण",
+		"TestMatrix":             "परीक्षण मैट्रिक्स",
+		"TestPlan":               "परीक्षण योजना",
+		"TestProcedures":         "परीक्षण प्रक्रियाएँ",
+		"ProcedureList":          "प्रक्रिया सूची",
+		"Title":                  "शीर्षक",
+		"Procedure":              "प्रक्रिया",
+		"TestResultFormat":       "परीक्षण परिणाम प्रारूप",
+		"SlNo":                   "क्र. सं.",
+		"NotApplicable":          "लागू नहीं",
+		"TelecommandList":        "दूरादेश सूची",
+		"Mnemonic":               "स्मृति-संकेत",
+		"Code":                   "कोड",
+		"Type":                   "प्रकार",
+		"VerificationTM":         "सत्यापन TM",
+		"Criticality":            "महत्वपूर्णता",
+		"TelemetryList":          "दूरमिति प्राचल सूची",
+		"Channel":                "चैनल",
+		"Units":                  "इकाई",
+		"Calibration":            "अंशांकन",
+		"LowerLimit":             "निम्न सीमा",
+		"UpperLimit":             "उच्च सीमा",
+		"StatusDisplays":         "संभावित स्थिति प्रदर्शन",
+		"Value":                  "मान",
+		"Status":                 "स्थिति",
+		"Action":                 "कार्रवाई",
+		"TCToSend":               "भेजा जाने वाला TC",
+		"TMToVerify":             "सत्यापित किया जाने वाला TM",
+		"ExpectedValue":          "अपेक्षित मान",
+		"Tolerance":              "सहनशीलता",
+		"WaitTime":               "प्रतीक्षा समय",
+		"Configuration":          "विन्यास",
+		"RequirementID":          "आवश्यकता सं.",
+		"Requirement":            "आवश्यकता",
+		"Procedures":             "प्रक्रियाएँ",
+		"Traceability":           "आवश्यकता अनुरेखण मैट्रिक्स",
+		"UncoveredRequirements":  "किसी प्रक्रिया द्वारा असत्यापित आवश्यकताएँ",
+		"UnknownRequirements":    "प्रक्रियाओं में संदर्भित किंतु अपरिभाषित आवश्यकताएँ",
+		"UnreadableProcedures":   "पढ़ी न जा सकने के कारण छोड़ी गई प्रक्रियाएँ",
+		"TestReport":             "एकीकृत अंतरिक्षयान परीक्षण रिपोर्ट",
+		"Summary":                "सारांश",
+		"TestDate":               "परीक्षण दिनांक",
+		"Operator":               "संचालक",
+		"Passed":                 "सफल",
+		"Failed":                 "विफल",
+		"NotRun":                 "नहीं चलाया गया",
+		"Observed":               "प्रेक्षित मान",
+		"Result":                 "परिणाम",
+		"AnomalyRef":             "विसंगति संदर्भ",
+		"OutOfLimits":            "सीमा से बाहर के नमूने",
+		"Unattributed":           "अनिर्दिष्ट",
+		"Time":                   "समय",
+		"Step":                   "चरण",
+		"ReviewComments":         "समीक्षा टिप्पणियाँ",
+		"ReviewCopy":             "समीक्षा प्रति",
+		"Section":                "खंड",
+		"Item":                   "मद",
+		"Comment":                "टिप्पणी",
+		"Author":                 "लेखक",
+		"Replies":                "उत्तर",
+		"Open":                   "खुली",
+		"Resolved":               "निराकृत",
+		"ItemRemoved":            "हटाया गया",
+		"MnemonicCheck":          "स्मृति-संकेत जाँच",
+		"UndefinedMnemonics":     "TC/TM सूची में अपरिभाषित स्मृति-संकेत",
+		"MisspelledMnemonics":    "संभावित गलत वर्तनी वाले स्मृति-संकेत",
+		"Suggestion":             "संभावित सही रूप",
+		"UnusedTelecommands":     "किसी प्रक्रिया में अप्रयुक्त दूरादेश",
+		"UnusedTelemetry":        "किसी प्रक्रिया में अप्रयुक्त दूरमिति प्राचल",
+		"NoMnemonicProblems":     "प्रक्रियाओं में प्रयुक्त सभी स्मृति-संकेत परिभाषित हैं, तथा प्रत्येक दूरादेश और दूरमिति प्राचल प्रयुक्त है।",
+		"AbstractText": `यह दस्तावेज़ #satClass श्रेणी के उपग्रह #satName की #ssName उपप्रणाली का संक्षिप्त विवरण देता है, तथा एकीकृत उपग्रह परीक्षण (IST) से संबंधित सभी पहलुओं को प्रस्तुत करता है, अर्थात
+	- TM और TC के स्मृति-संकेत
+	- TM पृष्ठ
+	- TM प्राचलों के संभावित स्थिति प्रदर्शन
+	- IST परीक्षण मैट्रिक्स
+	- IST योजनाएँ
+	- IST प्रक्रियाएँ
+	- IST परीक्षण रिपोर्ट प्रारूप
+	- कोई विशिष्ट आवश्यकताएँ
+
+	उपरोक्त पहलुओं को विषय सूची में दिए गए विभिन्न अध्यायों में शामिल किया गया है।`,
+	},
+}
+
+// blockMessages are whole paragraphs. In a bilingual document they are
+// repeated one after the other instead of being joined on one line.
+var blockMessages = map[string]bool{
+	"AbstractText": true,
+}
+
+// getMessage returns the text of key in the language, falling back to
+// English when the catalogue has no translation.
+func getMessage(language string, key string) string {
+	text, ok := messages[language][key]
+	if !ok {
+		text = messages["en"][key]
+	}
+	return text
+}
+
+// getMessageDefinitions returns the #let definitions of every catalogue entry
+// for the document language. The language is "en", "hi" or "bilingual".
+func getMessageDefinitions(language string) string {
+	keys := make([]string, 0)
+	for key := range messages["en"] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	content := ""
+	for _, key := range keys {
+		content = content + "#let msg" + key + " = [" + getMessageText(language, key) + "]\n"
+	}
+	return content
+}
+
+// getMessageText returns what #msg<key> shows in a document of the language,
+// for places that take plain text instead of markup.
+func getMessageText(language string, key string) string {
+	switch strings.ToLower(language) {
+	case "hi":
+		return getMessage("hi", key)
+	case "bilingual":
+		hindi := getMessage("hi", key)
+		english := getMessage("en", key)
+		if hindi == english {
+			return english
+		} else if blockMessages[key] {
+			return hindi + "\n\n\t" + english
+		}
+		return hindi + " / " + english
+	default:
+		return getMessage("en", key)
+	}
+}
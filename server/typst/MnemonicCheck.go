@@ -0,0 +1,56 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"intDocument/server/tmtc"
+)
+
+// makeMnemonicCheck returns the annexure listing the mnemonic problems found
+// in the test procedures.
+func makeMnemonicCheck(documentName string, user database.User) (string, bool) {
+	content := `
+	= #msgMnemonicCheck
+	`
+	errMsg, report, ok := tmtc.CheckDocument(documentName, user)
+	if !ok {
+		fmt.Println("Error in Mnemonic Check: " + errMsg)
+		content = content + "#" + quoteString("Error in Mnemonic Check: "+errMsg) + "\n\n#pagebreak()"
+		return content, true
+	}
+	if len(report.Undefined) == 0 && len(report.Misspelled) == 0 && len(report.UnusedTelecommands) == 0 && len(report.UnusedTelemetry) == 0 {
+		content = content + "#msgNoMnemonicProblems\n\n#pagebreak()"
+		return content, true
+	}
+
+	if len(report.Undefined) > 0 {
+		rows := make([][]string, 0)
+		for _, ref := range report.Undefined {
+			rows = append(rows, []string{ref.Mnemonic, ref.Item})
+		}
+		content = content + addRecordTable([]string{"#msgMnemonic", "#msgProcedure"}, rows, "msgUndefinedMnemonics") + "\n"
+	}
+	if len(report.Misspelled) > 0 {
+		rows := make([][]string, 0)
+		for _, ref := range report.Misspelled {
+			rows = append(rows, []string{ref.Mnemonic, ref.Item, ref.Suggestion})
+		}
+		content = content + addRecordTable([]string{"#msgMnemonic", "#msgProcedure", "#msgSuggestion"}, rows, "msgMisspelledMnemonics") + "\n"
+	}
+	if len(report.UnusedTelecommands) > 0 {
+		rows := make([][]string, 0)
+		for _, mnemonic := range report.UnusedTelecommands {
+			rows = append(rows, []string{mnemonic})
+		}
+		content = content + addRecordTable([]string{"#msgMnemonic"}, rows, "msgUnusedTelecommands") + "\n"
+	}
+	if len(report.UnusedTelemetry) > 0 {
+		rows := make([][]string, 0)
+		for _, mnemonic := range report.UnusedTelemetry {
+			rows = append(rows, []string{mnemonic})
+		}
+		content = content + addRecordTable([]string{"#msgMnemonic"}, rows, "msgUnusedTelemetry") + "\n"
+	}
+	content = content + "#pagebreak()"
+	return content, true
+}
@@ -0,0 +1,36 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+)
+
+// addProcedureContent renders a structured procedure item as a table with one
+// row per step.
+func addProcedureContent(name string, title string, value string, landscape bool) string {
+	procedure, err := database.ParseProcedure(value)
+	if err != nil {
+		fmt.Println(err.Error())
+		return "Procedure " + name + " cannot be read\n"
+	}
+	headers := []string{"#msgAction", "#msgTCToSend", "#msgTMToVerify", "#msgExpectedValue", "#msgTolerance", "#msgWaitTime", "#msgRemarks"}
+	rows := make([][]string, 0)
+	for _, step := range procedure.Steps {
+		rows = append(rows, []string{step.Action, step.Telecommand, step.Telemetry, step.ExpectedValue, step.Tolerance, step.WaitTime, step.Remarks})
+	}
+
+	content := ""
+	if landscape {
+		content = "#page(flipped: true)[\n"
+	}
+	content = content + "#pagebreak(weak: true)\n"
+	content = content + "=== #" + quoteString(name) + "\n\n"
+	if title != "" {
+		content = content + "#" + quoteString(title) + "\n\n"
+	}
+	content = content + addRecordTable(headers, rows, "[#msgProcedure: ] + "+quoteString(name))
+	if landscape {
+		content = content + "]\n"
+	}
+	return content
+}
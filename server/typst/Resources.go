@@ -0,0 +1,89 @@
+package typst
+
+import (
+	"intDocument/server/config"
+	"intDocument/server/database"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Packages imported by the generated files, as namespace/name/version.
+var requiredPackages = []string{
+	"preview/cmarker/0.1.0",
+}
+
+// getResourceOptions returns the typst command line options pointing to the
+// local package and font directories. Relative paths are resolved against
+// the working directory since typst runs inside the client directory.
+func getResourceOptions() []string {
+	options := make([]string, 0)
+	if config.Config.TypstPackagePath != "" {
+		path, err := filepath.Abs(config.Config.TypstPackagePath)
+		if err == nil {
+			options = append(options, "--package-path", path)
+		}
+	}
+	return append(options, getFontOptions()...)
+}
+
+// getFontOptions returns only the font directory option, which is all that
+// "typst fonts" accepts.
+func getFontOptions() []string {
+	options := make([]string, 0)
+	if config.Config.TypstFontPath != "" {
+		path, err := filepath.Abs(config.Config.TypstFontPath)
+		if err == nil {
+			options = append(options, "--font-path", path)
+		}
+	}
+	return options
+}
+
+// CheckOfflineResources reports the packages and fonts that are missing from
+// the local directories. An empty list means the document can be compiled
+// without network access.
+func CheckOfflineResources() []string {
+	problems := make([]string, 0)
+
+	packagePath := config.Config.TypstPackagePath
+	for _, pkg := range requiredPackages {
+		manifest := filepath.Join(packagePath, pkg, "typst.toml")
+		_, err := os.Stat(manifest)
+		if err != nil {
+			problems = append(problems, "Typst package "+pkg+" not found in "+packagePath)
+		}
+	}
+
+	fontPath := config.Config.TypstFontPath
+	_, err := os.Stat(fontPath)
+	if err != nil {
+		problems = append(problems, "Font directory "+fontPath+" not found")
+	}
+
+	options := []string{"fonts"}
+	options = append(options, getFontOptions()...)
+	output, err := exec.Command("typst", options...).CombinedOutput()
+	if err != nil {
+		problems = append(problems, "Cannot list Typst fonts: "+err.Error())
+		return problems
+	}
+	available := make(map[string]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		available[strings.ToLower(strings.TrimSpace(line))] = true
+	}
+	fonts := database.GetLayoutFonts()
+	names := make([]string, 0, len(fonts))
+	for font := range fonts {
+		names = append(names, font)
+	}
+	sort.Strings(names)
+	for _, font := range names {
+		if !available[strings.ToLower(font)] {
+			problems = append(problems, "Font "+font+" used by "+strings.Join(fonts[font], ", ")+" not available to Typst")
+		}
+	}
+	return problems
+}
@@ -0,0 +1,62 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"strconv"
+)
+
+// getReviewMark marks every page of the review copy in the background.
+func getReviewMark() string {
+	return "#set page(background: rotate(-45deg, text(64pt, fill: luma(225))[#msgReviewCopy]))\n"
+}
+
+// makeReviewComments returns the annexure of the review copy with every
+// comment thread of the document, open ones first. Each thread is listed
+// with the current position of its item.
+func makeReviewComments(documentName string, user database.User, language string) (string, bool) {
+	errMsg, comments, ok := database.GetComments(documentName, user)
+	if !ok {
+		fmt.Println("Error in Review Comments: " + errMsg)
+		return "", false
+	}
+	content := `
+	= #msgReviewComments
+	`
+	headers := []string{"#msgSection", "#msgItem", "#msgComment", "#msgAuthor", "#msgReplies", "#msgStatus"}
+	rows := make([][]string, 0)
+	resolvedRows := make([][]string, 0)
+	for _, comment := range comments.Comments {
+		text := comment.Text
+		if comment.Quote != "" {
+			text = "\"" + comment.Quote + "\": " + text
+		}
+		replies := ""
+		for _, reply := range comment.Replies {
+			replies = replies + reply.Author + ": " + reply.Text + "\n"
+		}
+		status := getMessageText(language, "Open")
+		if comment.Resolved {
+			status = getMessageText(language, "Resolved") + ", " + comment.ResolvedBy
+		}
+		item := getMessageText(language, "ItemRemoved")
+		if comment.Item >= 0 {
+			item = strconv.Itoa(comment.Item + 1)
+		}
+		row := []string{comment.Subsection, item, text, comment.Author + ", " + comment.Time, replies, status}
+		if comment.Resolved {
+			resolvedRows = append(resolvedRows, row)
+		} else {
+			rows = append(rows, row)
+		}
+	}
+	content = content + "#msgOpen: " + strconv.Itoa(len(rows)) + ", #msgResolved: " + strconv.Itoa(len(resolvedRows)) + "\n\n"
+	rows = append(rows, resolvedRows...)
+	if len(rows) == 0 {
+		content = content + "#msgNotApplicable\n"
+	} else {
+		content = content + addRecordTable(headers, rows, "msgReviewComments") + "\n"
+	}
+	content = content + "#pagebreak()"
+	return content, true
+}
@@ -2,79 +2,111 @@ package typst
 
 import (
 	"intDocument/server/database"
+	"strings"
 )
 
-func makeTestDetails(id string, documentName string, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
+func makeTestDetails(id string, documentName string, user database.User, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
 
 	content := `
-	= Test Details
+	= #msgTestDetails
 	`
 
-	errMsg, tm, ok := database.GetContent(documentName, "TestMatrix")
+	errMsg, tm, ok := database.GetContent(documentName, "TestMatrix", user)
 	if !ok {
 		content = content + "Error in Cehckout Interface: " + errMsg
 	}
-	tmContent := makeTestMatrix(id, tm, imageAdder, pdfAdder, tableAdder)
-	content = content + tmContent
+	errMsg, matrix, ok := database.GetTestMatrix(documentName, user)
+	if !ok {
+		content = content + "Error in Test Matrix: " + errMsg
+	}
+	tmContent := makeTestMatrix(id, tm, matrix, imageAdder, pdfAdder, tableAdder)
+	content = content + wrapSection(database.GetSectionLayout(layout, "TestMatrix"), tmContent)
 
-	errMsg, tp, ok := database.GetContent(documentName, "TestPlans")
+	errMsg, tp, ok := database.GetContent(documentName, "TestPlans", user)
 	if !ok {
 		content = content + "Error in Specific Requirements: " + errMsg
 	}
 	tpContent := makeTestPlan(id, tp, imageAdder, pdfAdder, tableAdder)
-	content = content + tpContent
+	content = content + wrapSection(database.GetSectionLayout(layout, "TestPlans"), tpContent)
 
-	errMsg, procedures, ok := database.GetContent(documentName, "TestProcedures")
+	errMsg, procedures, ok := database.GetContent(documentName, "TestProcedures", user)
 	if !ok {
 		content = content + "Error in Safety Requirements: " + errMsg
 	}
-	procContent := makeProcedures(id, procedures, imageAdder, pdfAdder, tableAdder)
+	procContent := makeProcedures(id, procedures, layout, imageAdder, pdfAdder, tableAdder)
 
-	content = content + procContent + "\n\n"
+	content = content + wrapSection(database.GetSectionLayout(layout, "TestProcedures"), procContent) + "\n\n"
 
 	content = content + "#pagebreak()"
 
 	return content, true
 }
 
-func makeTestMatrix(id string, tm database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+func makeTestMatrix(id string, tm database.Content, matrix database.TestMatrix, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Test Matrix
+	== #msgTestMatrix
 	`
-	tmContent := addContent(id, tm, imageAdder, pdfAdder, tableAdder)
-	content = content + tmContent
+	if tm.NoOfItems > 0 || len(matrix.Rows) == 0 {
+		tmContent := addContent(id, tm, imageAdder, pdfAdder, tableAdder)
+		content = content + tmContent
+	}
+	if len(matrix.Unreadable) > 0 {
+		content = content + "\n#msgUnreadableProcedures: #" + quoteString(strings.Join(matrix.Unreadable, ", ")) + "\n"
+	}
+	if len(matrix.Rows) > 0 {
+		content = content + "\n" + makeTestMatrixTable(matrix) + "\n"
+	}
 	return content
 }
 
+// makeTestMatrixTable returns the computed test matrix with one column per
+// test phase.
+func makeTestMatrixTable(matrix database.TestMatrix) string {
+	headers := []string{"#msgProcedure", "#msgTitle", "#msgConfiguration"}
+	for _, phase := range matrix.Phases {
+		headers = append(headers, "#"+quoteString(phase))
+	}
+	rows := make([][]string, 0)
+	for _, row := range matrix.Rows {
+		cells := []string{row.Procedure, row.Title, strings.Join(row.Configurations, ", ")}
+		for _, applicable := range row.Applicable {
+			if applicable {
+				cells = append(cells, "✓")
+			} else {
+				cells = append(cells, "")
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return addRecordTable(headers, rows, "msgTestMatrix")
+}
+
 func makeTestPlan(id string, tp database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
 	content := `
-	== Test Plan
+	== #msgTestPlan
 	`
 	tpContent := addContent(id, tp, imageAdder, pdfAdder, tableAdder)
 	content = content + tpContent
 	return content
 }
 
-func makeProcedures(id string, tp database.Content, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
-	content := "#pagebreak()" + "\n"
-	content = content + `
-	== Test Procedures
+func makeProcedures(id string, tp database.Content, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) string {
+	content := `
+	== #msgTestProcedures
 	#set block(spacing:1.2em)
 	#set par(leading:0.65em)
 	`
 	var proceduresTable string
-	proceduresTable = "Title, Procedure\n"
+	proceduresTable = "#msgTitle,#msgProcedure\n"
 	for i := 0; i < tp.NoOfItems; i++ {
 		proceduresTable = proceduresTable + tp.Captions[i] + "," + tp.FileName[i] + "\n"
 	}
 	tableId := tableAdder()
-	procTable := addTable(proceduresTable, "Procedure List", false, tableId)
+	procTable := addTableWithCaption(proceduresTable, "msgProcedureList", false, tableId)
 	content = content + procTable + "\n\n"
 	procedures := addContent(id, tp, imageAdder, pdfAdder, tableAdder)
 	content = content + procedures
-	content = content + `
-	#set block(spacing:1.5em)
-	#set par(leading:1.15em)
-	`
+	content = content + "\n#set block(spacing:" + formatNumber(layout.ParagraphSpacing) + "em)\n"
+	content = content + "#set par(leading:" + formatNumber(layout.LineSpacing) + "em)\n"
 	return content
 }
@@ -0,0 +1,142 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"os"
+	"strconv"
+)
+
+// getTestReport returns the test report of the procedures that have recorded
+// results: a summary of all procedures followed by the step results of each.
+func getTestReport(document database.DocumentDetails, subsystem database.SubsystemDetails, layout database.DocumentLayout, procedures []database.Procedure, results database.TestResults) string {
+	content := "#let docNum = " + quoteString(document.DocumentNumber) + "\n"
+	content = content + "#let docTitle = " + quoteString("IST Document for "+subsystem.SubsystemName+" system of "+subsystem.SatelliteName) + "\n"
+	content = content + "#let ssName = " + quoteString(subsystem.SubsystemName) + "\n"
+	content = content + "#let satName = " + quoteString(subsystem.SatelliteName) + "\n"
+	content = content + "#let satClass = " + quoteString(subsystem.SatelliteClass) + "\n"
+	content = content + getMessageDefinitions(document.Language)
+	content = content + getTextSettings(layout, document.Language)
+	content = content + getPageSettings(layout)
+	content = content + `
+	#align(center)[
+		#text(18pt)[*#msgTestReport*] #linebreak()
+		#satName #linebreak()
+		#ssName #linebreak()
+		#docNum
+	]
+	#v(1em)
+	`
+
+	byName := make(map[string]database.ProcedureResult)
+	for _, result := range results.Results {
+		byName[result.Procedure] = result
+	}
+
+	summary := make([][]string, 0)
+	for _, procedure := range procedures {
+		result, ok := byName[procedure.Name]
+		passed, failed := 0, 0
+		for _, step := range result.Steps {
+			if step.Status == "Pass" {
+				passed = passed + 1
+			} else if step.Status == "Fail" {
+				failed = failed + 1
+			}
+		}
+		notRun := len(procedure.Steps) - passed - failed
+		if !ok {
+			notRun = len(procedure.Steps)
+		}
+		summary = append(summary, []string{procedure.Name, procedure.Title, result.TestDate, result.Operator, strconv.Itoa(passed), strconv.Itoa(failed), strconv.Itoa(notRun)})
+	}
+	headers := []string{"#msgProcedure", "#msgTitle", "#msgTestDate", "#msgOperator", "#msgPassed", "#msgFailed", "#msgNotRun"}
+	content = content + "= #msgSummary\n"
+	content = content + addRecordTable(headers, summary, "msgSummary") + "\n"
+
+	content = content + "#set page(flipped: true)\n"
+	for _, procedure := range procedures {
+		result, ok := byName[procedure.Name]
+		if !ok {
+			continue
+		}
+		steps := make(map[int]database.StepResult)
+		for _, step := range result.Steps {
+			steps[step.Step] = step
+		}
+		content = content + "#pagebreak(weak: true)\n"
+		content = content + "= #" + quoteString(procedure.Name) + "\n\n"
+		if procedure.Title != "" {
+			content = content + "#" + quoteString(procedure.Title) + "\n\n"
+		}
+		content = content + "#msgTestDate: #" + quoteString(result.TestDate) + " #h(2em) #msgOperator: #" + quoteString(result.Operator) + "\n\n"
+		if procedure.ResultFormat != "" {
+			content = content + "#msgTestResultFormat: #" + quoteString(procedure.ResultFormat) + "\n\n"
+		}
+		rows := make([][]string, 0)
+		for i, step := range procedure.Steps {
+			observed := steps[i+1]
+			rows = append(rows, []string{step.Action, step.ExpectedValue, step.Tolerance, observed.Observed, observed.Status, observed.AnomalyRef, observed.Remarks})
+		}
+		stepHeaders := []string{"#msgAction", "#msgExpectedValue", "#msgTolerance", "#msgObserved", "#msgResult", "#msgAnomalyRef", "#msgRemarks"}
+		content = content + addRecordTable(stepHeaders, rows, "[#msgResult: ] + "+quoteString(procedure.Name)) + "\n"
+		if len(result.OutOfLimits) > 0 {
+			limitRows := make([][]string, 0)
+			for _, sample := range result.OutOfLimits {
+				step := strconv.Itoa(sample.Step)
+				if sample.Step == 0 {
+					step = getMessage(document.Language, "Unattributed")
+				}
+				limitRows = append(limitRows, []string{sample.Time, step, sample.Mnemonic, sample.Value, sample.LowerLimit, sample.UpperLimit})
+			}
+			limitHeaders := []string{"#msgTime", "#msgStep", "#msgMnemonic", "#msgValue", "#msgLowerLimit", "#msgUpperLimit"}
+			content = content + addRecordTable(limitHeaders, limitRows, "[#msgOutOfLimits: ] + "+quoteString(procedure.Name)) + "\n"
+		}
+	}
+	return content
+}
+
+func InitializeTestReport(id string, documentName string, user database.User) (string, bool) {
+	errMsg, document, ok := database.GetDocumentDetails(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Document doesn't exist", false
+	}
+	errMsg, subSystem, ok := database.GetSubsystemDetails(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Document doesn't exist", false
+	}
+	errMsg, layout, ok := database.GetLayout(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Layout", false
+	}
+	errMsg, procedures, ok := database.GetProcedures(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Procedures", false
+	}
+	errMsg, results, ok := database.GetTestResults(documentName, user)
+	if !ok {
+		fmt.Println(errMsg)
+		return "Cannot read Test Results", false
+	}
+	if len(results.Results) == 0 {
+		return "No Test Results recorded", false
+	}
+	err := os.MkdirAll(id, os.ModePerm)
+	if err != nil {
+		fmt.Println("Cannot Create Directory")
+		return "Cannot create Client Directory", false
+	}
+
+	fullContent := getTestReport(document, subSystem, layout, procedures, results)
+
+	typstFile := id + "/main.typ"
+	err = os.WriteFile(typstFile, []byte(fullContent), 0666)
+	if err != nil {
+		return "Cannot write Typst file", false
+	}
+	return "", true
+}
@@ -4,18 +4,18 @@ import (
 	"intDocument/server/database"
 )
 
-func makeTestResults(id string, documentName string, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
+func makeTestResults(id string, documentName string, user database.User, layout database.DocumentLayout, imageAdder func(string) (string, bool), pdfAdder func(string) (int, bool), tableAdder func() int) (string, bool) {
 	content := `
-	= Test Result Format
+	= #msgTestResultFormat
 	`
 
-	errMsg, eid, ok := database.GetContent(documentName, "Annexure-TestResultsFormat")
+	errMsg, eid, ok := database.GetContent(documentName, "Annexure-TestResultsFormat", user)
 	if !ok {
 		content = content + "Error in getting Test Results Format: " + errMsg
 		return "", false
 	}
 	trContent := addContent(id, eid, imageAdder, pdfAdder, tableAdder)
-	content = content + trContent + "\n\n"
+	content = content + wrapSection(database.GetSectionLayout(layout, "Annexure-TestResultsFormat"), trContent) + "\n\n"
 	content = content + "#pagebreak()"
 
 	return content, true
@@ -0,0 +1,51 @@
+package typst
+
+import (
+	"fmt"
+	"intDocument/server/database"
+	"strings"
+)
+
+// makeTraceability returns the requirements traceability annexure. It is
+// empty if the document has no requirements and no procedure refers to one.
+func makeTraceability(documentName string, user database.User) (string, bool) {
+	errMsg, trace, ok := database.GetTraceability(documentName, user)
+	if !ok {
+		fmt.Println("Error in Traceability: " + errMsg)
+		content := "\n= #msgTraceability\n\n#" + quoteString("Error in Traceability: "+errMsg) + "\n\n#pagebreak()"
+		return content, true
+	}
+	if len(trace.Rows) == 0 && len(trace.Unknown) == 0 && len(trace.Unreadable) == 0 {
+		return "", true
+	}
+	content := `
+	= #msgTraceability
+	`
+	if len(trace.Unreadable) > 0 {
+		content = content + "#msgUnreadableProcedures: #" + quoteString(strings.Join(trace.Unreadable, ", ")) + "\n\n"
+	}
+	if len(trace.Rows) > 0 {
+		headers := []string{"#msgRequirementID", "#msgRequirement", "#msgProcedures", "#msgTestResultFormat"}
+		rows := make([][]string, 0)
+		uncovered := make([][]string, 0)
+		for _, row := range trace.Rows {
+			rows = append(rows, []string{row.Requirement, row.Text, strings.Join(row.Procedures, ", "), strings.Join(row.ResultFormats, ", ")})
+			if !row.Covered {
+				uncovered = append(uncovered, []string{row.Requirement, row.Text})
+			}
+		}
+		content = content + addRecordTable(headers, rows, "msgTraceability") + "\n"
+		if len(uncovered) > 0 {
+			content = content + addRecordTable([]string{"#msgRequirementID", "#msgRequirement"}, uncovered, "msgUncoveredRequirements") + "\n"
+		}
+	}
+	if len(trace.Unknown) > 0 {
+		rows := make([][]string, 0)
+		for _, unknown := range trace.Unknown {
+			rows = append(rows, []string{unknown.Requirement, unknown.Procedure})
+		}
+		content = content + addRecordTable([]string{"#msgRequirementID", "#msgProcedure"}, rows, "msgUnknownRequirements") + "\n"
+	}
+	content = content + "#pagebreak()"
+	return content, true
+}